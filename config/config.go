@@ -59,10 +59,214 @@ func ConnectDB() (*gorm.DB, error) {
 }
 
 func MigrateDB(db *gorm.DB) error {
-	return db.AutoMigrate(
+	// Articles created before the publishing workflow were already public
+	hasArticleStatus := !db.Migrator().HasTable(&models.Article{}) || db.Migrator().HasColumn(&models.Article{}, "status")
+
+	// Likes were used as bookmarks before bookmarks had their own table
+	hasArticleBookmarks := db.Migrator().HasTable(&models.ArticleBookmark{})
+
+	// Points are backfilled from past activity and default badges are created with the tables
+	hasPointTransactions := db.Migrator().HasTable(&models.PointTransaction{})
+	hasBadges := db.Migrator().HasTable(&models.Badge{})
+
+	// Reading days are backfilled from reading histories and read points
+	hasReadingActivities := db.Migrator().HasTable(&models.ReadingActivity{})
+
+	// Slugs were not unique before, suffix duplicates so the unique index can be created
+	if db.Migrator().HasTable(&models.Article{}) && !db.Migrator().HasIndex(&models.Article{}, "Slug") {
+		err := dedupeArticleSlugs(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Likes were not unique before, remove unliked and duplicate rows so the unique index can be created
+	if db.Migrator().HasTable(&models.ArticleLiked{}) && !db.Migrator().HasIndex(&models.ArticleLiked{}, "idx_article_liked") {
+		err := dedupeArticleLikes(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := db.AutoMigrate(
 		&models.Administrator{},
+		&models.Category{},
+		&models.Tag{},
 		&models.Article{},
 		&models.User{},
 		&models.ArticleLiked{},
+		&models.Comment{},
+		&models.ArticleRevision{},
+		&models.ArticleSlug{},
+		&models.ArticleViewStat{},
+		&models.ArticleRanking{},
+		&models.ArticleRelation{},
+		&models.ArticleBookmark{},
+		&models.Collection{},
+		&models.CollectionItem{},
+		&models.ReadingHistory{},
+		&models.ReadingActivity{},
+		&models.Quiz{},
+		&models.QuizQuestion{},
+		&models.QuizOption{},
+		&models.QuizAttempt{},
+		&models.QuizAnswer{},
+		&models.Course{},
+		&models.CourseLesson{},
+		&models.CourseEnrollment{},
+		&models.CourseLessonCompletion{},
+		&models.Certificate{},
+		&models.PointTransaction{},
+		&models.Badge{},
+		&models.UserBadge{},
+		&models.AuthorFollow{},
+		&models.CategoryFollow{},
+		&models.Notification{},
 	)
+	if err != nil {
+		return err
+	}
+
+	if !hasArticleStatus {
+		err = db.Model(&models.Article{}).
+			Where("1 = 1").
+			UpdateColumns(map[string]interface{}{"status": models.ArticlePublished, "published_at": gorm.Expr("created_at")}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if !hasArticleBookmarks {
+		err = db.Exec(`INSERT INTO article_bookmarks (created_at, user_id, article_id)
+			SELECT MIN(created_at), user_id, article_id FROM article_likeds
+			WHERE deleted_at IS NULL GROUP BY user_id, article_id`).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if !hasPointTransactions {
+		err = backfillPoints(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !hasReadingActivities {
+		err = backfillReadingActivities(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !hasBadges {
+		badges := append([]models.Badge{}, models.DefaultBadges...)
+		err = db.Create(&badges).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return MigrateArticleSearch(db)
+}
+
+// Award points for activity done before the points ledger existed
+func backfillPoints(db *gorm.DB) error {
+	queries := []string{
+		`INSERT INTO point_transactions (created_at, user_id, source, source_id, points)
+			SELECT created_at, id, ?, id, ? FROM users WHERE verified = true AND deleted_at IS NULL`,
+		`INSERT INTO point_transactions (created_at, user_id, source, source_id, points)
+			SELECT completed_at, user_id, ?, article_id, ? FROM reading_histories WHERE completed_at IS NOT NULL`,
+		`INSERT INTO point_transactions (created_at, user_id, source, source_id, points)
+			SELECT MIN(created_at), user_id, ?, article_id, ? FROM article_likeds
+			WHERE deleted_at IS NULL GROUP BY user_id, article_id`,
+		`INSERT INTO point_transactions (created_at, user_id, source, source_id, points)
+			SELECT MIN(created_at), user_id, ?, article_id, ? FROM quiz_attempts
+			WHERE passed = true GROUP BY user_id, article_id`,
+	}
+	sources := []string{
+		models.PointSourceVerifyEmail,
+		models.PointSourceReadArticle,
+		models.PointSourceLikeArticle,
+		models.PointSourcePassQuiz,
+	}
+
+	for i, query := range queries {
+		err := db.Exec(query, sources[i], models.PointValues[sources[i]]).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reading days known before they were recorded, grouped like SaveReadingActivity
+func backfillReadingActivities(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO reading_activities (user_id, day, first_read_at, last_read_at)
+		SELECT user_id, DATE_FORMAT(read_at, '%Y-%m-%d'), MIN(read_at), MAX(read_at) FROM (
+			SELECT user_id, created_at AS read_at FROM reading_histories
+			UNION ALL SELECT user_id, last_read_at FROM reading_histories
+			UNION ALL SELECT user_id, completed_at FROM reading_histories WHERE completed_at IS NOT NULL
+			UNION ALL SELECT user_id, created_at FROM point_transactions WHERE source = ?
+		) AS activity GROUP BY user_id, DATE_FORMAT(read_at, '%Y-%m-%d')`, models.PointSourceReadArticle).Error
+}
+
+// Delete soft deleted likes and keep the oldest like of each user and article
+func dedupeArticleLikes(db *gorm.DB) error {
+	err := db.Exec("DELETE FROM article_likeds WHERE deleted_at IS NOT NULL").Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`DELETE newer FROM article_likeds newer
+		JOIN article_likeds older ON older.user_id = newer.user_id AND older.article_id = newer.article_id AND older.id < newer.id`).Error
+}
+
+// Rename duplicate and empty article slugs with -2, -3 suffix, the oldest article keeps the slug
+func dedupeArticleSlugs(db *gorm.DB) error {
+	var articles []models.Article
+
+	err := db.Unscoped().Select("id", "slug").Order("id asc").Find(&articles).Error
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool, len(articles))
+	for _, article := range articles {
+		base := article.Slug
+		if base == "" {
+			base = "article"
+		}
+
+		slug := base
+		for i := 2; used[slug]; i++ {
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[slug] = true
+
+		if slug == article.Slug {
+			continue
+		}
+
+		err = db.Unscoped().Model(&models.Article{}).Where("id = ?", article.ID).UpdateColumn("slug", slug).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Create FULLTEXT index used by article search, only supported on MySQL
+func MigrateArticleSearch(db *gorm.DB) error {
+	if db.Dialector.Name() != "mysql" {
+		return nil
+	}
+
+	if db.Migrator().HasIndex(&models.Article{}, "idx_articles_search") {
+		return nil
+	}
+
+	return db.Exec("ALTER TABLE articles ADD FULLTEXT INDEX idx_articles_search (title, abstract, description, label)").Error
 }
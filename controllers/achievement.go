@@ -0,0 +1,341 @@
+package controllers
+
+import (
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AchievementController interface {
+	GetAchievements(c echo.Context) error
+	UpdateAchievementSettings(c echo.Context) error
+	GetWeeklyLeaderboard(c echo.Context) error
+	GetBadges(c echo.Context) error
+	CreateBadge(c echo.Context) error
+	UpdateBadge(c echo.Context) error
+	DeleteBadge(c echo.Context) error
+}
+
+type achievementController struct {
+	achievementUsecase usecase.AchievementUsecase
+}
+
+func NewAchievementController(achievementUsecase usecase.AchievementUsecase) AchievementController {
+	return &achievementController{achievementUsecase}
+}
+
+// Controller for get points, streak and badges of the logged in User
+func (c *achievementController) GetAchievements(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	achievement, err := c.achievementUsecase.GetAchievements(uint(userId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching achievements",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get achievements",
+			achievement,
+		),
+	)
+}
+
+// Controller for set timezone and leaderboard visibility of the logged in User
+func (c *achievementController) UpdateAchievementSettings(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.AchievementSettingsRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Timezone is required",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	achievement, err := c.achievementUsecase.UpdateAchievementSettings(uint(userId), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to update achievement settings",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Achievement settings have been updated",
+			achievement,
+		),
+	)
+}
+
+// Controller for get Users with the most points this week
+func (c *achievementController) GetWeeklyLeaderboard(ctx echo.Context) error {
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	// Guests get the leaderboard without their own rank
+	userId, _ := m.OptionalUser(ctx)
+
+	leaderboard, err := c.achievementUsecase.GetWeeklyLeaderboard(userId, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching leaderboard",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get leaderboard",
+			leaderboard,
+		),
+	)
+}
+
+// Controller for get all badge rules
+func (c *achievementController) GetBadges(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	badges, err := c.achievementUsecase.GetBadges()
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching badges",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get badges",
+			badges,
+		),
+	)
+}
+
+// Controller for create a badge rule
+func (c *achievementController) CreateBadge(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.BadgeRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Code, name, a known metric and a threshold are required",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	badge, err := c.achievementUsecase.CreateBadge(req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to create badge",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusCreated,
+		helpers.NewResponse(
+			http.StatusCreated,
+			"Successfully created badge",
+			badge,
+		),
+	)
+}
+
+// Controller for update a badge rule
+func (c *achievementController) UpdateBadge(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get badge ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.BadgeRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Code, name, a known metric and a threshold are required",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	badge, err := c.achievementUsecase.UpdateBadge(uint(id), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to update badge",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully updated badge",
+			badge,
+		),
+	)
+}
+
+// Controller for delete a badge rule
+func (c *achievementController) DeleteBadge(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get badge ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	err = c.achievementUsecase.DeleteBadge(uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to delete badge",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully deleted badge",
+			nil,
+		),
+	)
+}
@@ -7,15 +7,28 @@ import (
 	"go_bedu/models"
 	"go_bedu/usecase"
 	"net/http"
+	"path"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 type ArticleController interface {
 	GetAllArticles(c echo.Context) error
+	GetTrendingArticles(c echo.Context) error
+	GetPopularArticles(c echo.Context) error
+	GetPersonalizedFeed(c echo.Context) error
 	GetArticleById(c echo.Context) error
+	GetArticleBySlug(c echo.Context) error
+	GetRelatedArticles(c echo.Context) error
+	GetAdminArticles(c echo.Context) error
+	GetAdminArticleById(c echo.Context) error
+	UpdateArticleStatus(c echo.Context) error
+	ScheduleArticle(c echo.Context) error
+	CancelArticleSchedule(c echo.Context) error
+	PreviewArticle(c echo.Context) error
 	CreateArticle(c echo.Context) error
 	UpdateArticle(c echo.Context) error
 	DeleteArticle(c echo.Context) error
@@ -23,27 +36,47 @@ type ArticleController interface {
 
 type articleController struct {
 	articleUsecase usecase.ArticleUsecase
+	viewCounter    usecase.ViewCounter
 }
 
-func NewArticleController(articleUsecase usecase.ArticleUsecase) ArticleController {
-	return &articleController{articleUsecase}
+func NewArticleController(articleUsecase usecase.ArticleUsecase, viewCounter usecase.ViewCounter) ArticleController {
+	return &articleController{articleUsecase, viewCounter}
 }
 
 // Controller for Get All Article from DB with optional pagination
 func (c *articleController) GetAllArticles(ctx echo.Context) error {
 	pageParam := ctx.QueryParam("page")
 	page, err := strconv.Atoi(pageParam)
-	if err != nil {
+	if err != nil || page < 1 {
 		page = 1
 	}
 
 	limitParam := ctx.QueryParam("limit")
 	limit, err := strconv.Atoi(limitParam)
-	if err != nil {
+	if err != nil || limit < 1 {
 		limit = 10
 	}
 
-	articles, count, err := c.articleUsecase.GetAllArticles(page, limit)
+	var (
+		articles []dtos.ArticleDetailResponse
+		count    int
+	)
+
+	filter := dtos.ArticleFilter{
+		Category: ctx.QueryParam("category"),
+		Tag:      ctx.QueryParam("tag"),
+	}
+
+	// Search mode when keyword is provided
+	query := strings.TrimSpace(ctx.QueryParam("q"))
+	if query != "" {
+		articles, count, err = c.articleUsecase.SearchArticles(query, filter, page, limit)
+	} else {
+		articles, count, err = c.articleUsecase.GetAllArticles(filter, page, limit)
+	}
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
 	if err != nil {
 
 		return ctx.JSON(
@@ -69,6 +102,138 @@ func (c *articleController) GetAllArticles(ctx echo.Context) error {
 	)
 }
 
+// Controller for Get trending Article from the cached ranking
+func (c *articleController) GetTrendingArticles(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	articles, count, err := c.articleUsecase.GetTrendingArticles(page, limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed fetching trending articles",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get trending articles",
+			articles,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for Get most liked Article of a period from the cached ranking
+func (c *articleController) GetPopularArticles(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	articles, count, err := c.articleUsecase.GetPopularArticles(ctx.QueryParam("period"), page, limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching popular articles",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get popular articles",
+			articles,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for Get Article feed ranked by the User interests
+func (c *articleController) GetPersonalizedFeed(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for User Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	articles, count, err := c.articleUsecase.GetPersonalizedFeed(uint(userId), page, limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed fetching article feed",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get article feed",
+			articles,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
 // Controller for get Article by ID from parameter
 func (c *articleController) GetArticleById(ctx echo.Context) error {
 	id, err := strconv.Atoi(ctx.Param("id"))
@@ -84,6 +249,9 @@ func (c *articleController) GetArticleById(ctx echo.Context) error {
 	}
 
 	article, err := c.articleUsecase.GetArticleByID(uint(id))
+	if err == nil {
+		err = markArticleLikedByMe(ctx, c.articleUsecase, &article)
+	}
 	if err != nil {
 		return ctx.JSON(
 			http.StatusInternalServerError,
@@ -94,6 +262,195 @@ func (c *articleController) GetArticleById(ctx echo.Context) error {
 			),
 		)
 	}
+	c.viewCounter.RecordView(article.ArticleID, articleViewer(ctx))
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get article",
+			article,
+		),
+	)
+}
+
+// Controller for get related Article of an Article
+func (c *articleController) GetRelatedArticles(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 5
+	}
+
+	articles, err := c.articleUsecase.GetRelatedArticles(uint(id), limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get related articles",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get related articles",
+			articles,
+		),
+	)
+}
+
+// Controller for get Article by slug, old slugs are redirected to the current slug
+func (c *articleController) GetArticleBySlug(ctx echo.Context) error {
+	article, currentSlug, err := c.articleUsecase.GetArticleBySlug(ctx.Param("slug"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	if currentSlug != "" {
+		return ctx.Redirect(http.StatusMovedPermanently, path.Join(path.Dir(ctx.Request().URL.Path), currentSlug))
+	}
+
+	err = markArticleLikedByMe(ctx, c.articleUsecase, &article)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed to get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+	c.viewCounter.RecordView(article.ArticleID, articleViewer(ctx))
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get article",
+			article,
+		),
+	)
+}
+
+// Controller for Get Articles of every status for admin
+func (c *articleController) GetAdminArticles(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	filter := dtos.ArticleFilter{
+		Category: ctx.QueryParam("category"),
+		Tag:      ctx.QueryParam("tag"),
+		Status:   ctx.QueryParam("status"),
+	}
+
+	articles, count, err := c.articleUsecase.GetAdminArticles(filter, page, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching articles",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get all article",
+			articles,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for get Article of any status by ID for admin
+func (c *articleController) GetAdminArticleById(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	article, err := c.articleUsecase.GetAdminArticleByID(uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
 
 	return ctx.JSON(
 		http.StatusOK,
@@ -105,6 +462,223 @@ func (c *articleController) GetArticleById(ctx echo.Context) error {
 	)
 }
 
+// Controller for move Article through the publishing workflow
+func (c *articleController) UpdateArticleStatus(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.ArticleStatusRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Article status is not valid",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	article, err := c.articleUsecase.UpdateArticleStatus(uint(id), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to update article status",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article status updated successfully",
+			article,
+		),
+	)
+}
+
+// Controller for schedule or reschedule Article publishing
+func (c *articleController) ScheduleArticle(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.ArticleScheduleRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Schedule time must use RFC3339 format",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Schedule time cannot be empty",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	article, err := c.articleUsecase.ScheduleArticle(uint(id), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to schedule article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article scheduled successfully",
+			article,
+		),
+	)
+}
+
+// Controller for cancel scheduled Article publishing
+func (c *articleController) CancelArticleSchedule(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	article, err := c.articleUsecase.CancelArticleSchedule(uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to cancel article schedule",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article schedule cancelled successfully",
+			article,
+		),
+	)
+}
+
+// Controller for preview rendered Markdown description without saving
+func (c *articleController) PreviewArticle(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.ArticlePreviewRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Description cannot be empty",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully preview article",
+			c.articleUsecase.PreviewArticle(req),
+		),
+	)
+}
+
 func (c *articleController) CreateArticle(ctx echo.Context) error {
 	var articleInput dtos.CreateArticlesRequest
 	// Get Admin id from JWT Cookie
@@ -122,6 +696,17 @@ func (c *articleController) CreateArticle(ctx echo.Context) error {
 		)
 	}
 
+	if err := ctx.Validate(&articleInput); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Article field is not valid",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
 	// Upload File and validate file extension (jpg, png, and jpeg).
 	thumbnail, err := ctx.FormFile("thumbnail")
 	if err != nil {
@@ -284,7 +869,7 @@ func (c *articleController) UpdateArticle(ctx echo.Context) error {
 		)
 	}
 
-	article, err := c.articleUsecase.GetArticleByID(uint(id))
+	article, err := c.articleUsecase.GetAdminArticleByID(uint(id))
 	if article.ArticleID == 0 {
 		return ctx.JSON(
 			http.StatusBadRequest,
@@ -451,3 +1036,12 @@ func (c *articleController) DeleteArticle(ctx echo.Context) error {
 		),
 	)
 }
+
+// Identify the reader for view dedup, logged in users by ID and guests by IP from the IPExtractor set in main
+func articleViewer(ctx echo.Context) string {
+	if userId, err := m.IsUser(ctx); err == nil {
+		return "user:" + strconv.Itoa(userId)
+	}
+
+	return "ip:" + ctx.RealIP()
+}
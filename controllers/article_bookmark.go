@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ArticleBookmarkControllers interface {
+	GetBookmarksController(c echo.Context) error
+	BookmarkArticleController(c echo.Context) error
+	RemoveBookmarkController(c echo.Context) error
+}
+
+type articleBookmarkControllers struct {
+	bookmarkUsecase usecase.ArticleBookmarkUsecase
+	articleUsecase  usecase.ArticleUsecase
+}
+
+func NewArticleBookmarkControllers(bookmarkUsecase usecase.ArticleBookmarkUsecase, articleUsecase usecase.ArticleUsecase) ArticleBookmarkControllers {
+	return &articleBookmarkControllers{
+		bookmarkUsecase: bookmarkUsecase,
+		articleUsecase:  articleUsecase,
+	}
+}
+
+// Controller for get bookmarked Articles of the logged in User
+func (c *articleBookmarkControllers) GetBookmarksController(ctx echo.Context) error {
+	id, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	articles, count, err := c.bookmarkUsecase.GetBookmarks(uint(id), page, limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed fetching bookmarks",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get bookmarks",
+			articles,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for bookmark an Article, bookmarking twice is a no-op
+func (c *articleBookmarkControllers) BookmarkArticleController(ctx echo.Context) error {
+	id, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	idArticle, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Cannot get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	dataArticle, err := c.articleUsecase.GetArticleByID(uint(idArticle))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed to get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	if dataArticle.ArticleID == 0 {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Article not found",
+				nil,
+			),
+		)
+	}
+
+	bookmark, err := c.bookmarkUsecase.BookmarkArticle(uint(id), dataArticle.ArticleID)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Cannot bookmark article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article has been bookmarked",
+			bookmark,
+		),
+	)
+}
+
+// Controller for remove bookmark of an Article, removing a missing bookmark is a no-op
+func (c *articleBookmarkControllers) RemoveBookmarkController(ctx echo.Context) error {
+	id, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	idArticle, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Cannot get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	bookmark, err := c.bookmarkUsecase.RemoveBookmark(uint(id), uint(idArticle))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Cannot remove bookmark",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Bookmark has been removed",
+			bookmark,
+		),
+	)
+}
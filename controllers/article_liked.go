@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"go_bedu/dtos"
 	"go_bedu/helpers"
 	m "go_bedu/middlewares"
 	"go_bedu/usecase"
@@ -12,7 +13,8 @@ import (
 
 type ArticleLikedControllers interface {
 	GetArticleLikedByUserIdController(c echo.Context) error
-	CreateArticleLikedController(c echo.Context) error
+	LikeArticleController(c echo.Context) error
+	UnlikeArticleController(c echo.Context) error
 }
 
 type articleLikedControllers struct {
@@ -62,7 +64,8 @@ func (c *articleLikedControllers) GetArticleLikedByUserIdController(ctx echo.Con
 	)
 }
 
-func (c *articleLikedControllers) CreateArticleLikedController(ctx echo.Context) error {
+// Controller for like an Article, liking twice is a no-op
+func (c *articleLikedControllers) LikeArticleController(ctx echo.Context) error {
 	id, err := m.IsUser(ctx)
 	if err != nil {
 		return ctx.JSON(
@@ -110,13 +113,61 @@ func (c *articleLikedControllers) CreateArticleLikedController(ctx echo.Context)
 		)
 	}
 
-	articleLiked, err := c.articleLikedUsecase.CreateArticleLiked(uint(id), dataArticle.ArticleID)
+	articleLike, err := c.articleLikedUsecase.LikeArticle(uint(id), dataArticle.ArticleID)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Cannot like article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article has been liked",
+			articleLike,
+		),
+	)
+}
+
+// Controller for remove like of an Article, removing a missing like is a no-op
+func (c *articleLikedControllers) UnlikeArticleController(ctx echo.Context) error {
+	id, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	idArticle, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		return ctx.JSON(
 			http.StatusBadRequest,
 			helpers.NewErrorResponse(
 				http.StatusBadRequest,
-				"Cannot bookmark article",
+				"Cannot get article",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	articleLike, err := c.articleLikedUsecase.UnlikeArticle(uint(id), uint(idArticle))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Cannot unlike article",
 				helpers.GetErrorData(err),
 			),
 		)
@@ -126,8 +177,28 @@ func (c *articleLikedControllers) CreateArticleLikedController(ctx echo.Context)
 		http.StatusOK,
 		helpers.NewResponse(
 			http.StatusOK,
-			"Article has been saved",
-			articleLiked,
+			"Article has been unliked",
+			articleLike,
 		),
 	)
 }
+
+// Fill liked_by_me of the articles when the request carries a user token
+func markLikedByMe(ctx echo.Context, articleUsecase usecase.ArticleUsecase, articles []dtos.ArticleDetailResponse) error {
+	userId, ok := m.OptionalUser(ctx)
+	if !ok {
+		return nil
+	}
+
+	return articleUsecase.MarkLikedByUser(userId, articles)
+}
+
+// Fill liked_by_me of a single article when the request carries a user token
+func markArticleLikedByMe(ctx echo.Context, articleUsecase usecase.ArticleUsecase, article *dtos.ArticleDetailResponse) error {
+	articles := []dtos.ArticleDetailResponse{*article}
+
+	err := markLikedByMe(ctx, articleUsecase, articles)
+	*article = articles[0]
+
+	return err
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ArticleRevisionController interface {
+	GetRevisions(c echo.Context) error
+	DiffRevisions(c echo.Context) error
+	RestoreRevision(c echo.Context) error
+}
+
+type articleRevisionController struct {
+	revisionUsecase usecase.ArticleRevisionUsecase
+}
+
+func NewArticleRevisionController(revisionUsecase usecase.ArticleRevisionUsecase) ArticleRevisionController {
+	return &articleRevisionController{revisionUsecase}
+}
+
+// Controller for Get revision history of an Article
+func (c *articleRevisionController) GetRevisions(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	revisions, err := c.revisionUsecase.GetRevisions(uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get article revisions",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get article revisions",
+			revisions,
+		),
+	)
+}
+
+// Controller for compare two revisions of an Article
+func (c *articleRevisionController) DiffRevisions(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	from, err := strconv.Atoi(ctx.QueryParam("from"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Query param from must be a revision number",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	to, err := strconv.Atoi(ctx.QueryParam("to"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Query param to must be a revision number",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	diff, err := c.revisionUsecase.DiffRevisions(uint(id), from, to)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get article revision diff",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get article revision diff",
+			diff,
+		),
+	)
+}
+
+// Controller for restore Article content from a revision
+func (c *articleRevisionController) RestoreRevision(ctx echo.Context) error {
+	adminId, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	revision, err := strconv.Atoi(ctx.Param("rev"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get revision number",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	article, err := c.revisionUsecase.RestoreRevision(uint(id), revision, uint(adminId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to restore article revision",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article revision restored successfully",
+			article,
+		),
+	)
+}
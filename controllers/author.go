@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthorController interface {
+	GetAuthor(c echo.Context) error
+}
+
+type authorController struct {
+	authorUsecase  usecase.AuthorUsecase
+	articleUsecase usecase.ArticleUsecase
+}
+
+func NewAuthorController(authorUsecase usecase.AuthorUsecase, articleUsecase usecase.ArticleUsecase) AuthorController {
+	return &authorController{authorUsecase, articleUsecase}
+}
+
+// Controller for get public profile of an Author with published Articles, paginated by article
+func (c *authorController) GetAuthor(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	// Guests get the profile without their follow status
+	userId, _ := m.OptionalUser(ctx)
+
+	author, count, err := c.authorUsecase.GetAuthor(userId, ctx.Param("username"), page, limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, author.Articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get author",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get author",
+			author,
+			page,
+			limit,
+			count,
+		),
+	)
+}
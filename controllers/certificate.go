@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CertificateController interface {
+	GetCertificates(c echo.Context) error
+	VerifyCertificate(c echo.Context) error
+}
+
+type certificateController struct {
+	certificateUsecase usecase.CertificateUsecase
+}
+
+func NewCertificateController(certificateUsecase usecase.CertificateUsecase) CertificateController {
+	return &certificateController{certificateUsecase}
+}
+
+// Controller for get course certificates of the logged in User
+func (c *certificateController) GetCertificates(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	certificates, count, err := c.certificateUsecase.GetCertificates(uint(userId), page, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching certificates",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get certificates",
+			certificates,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for verify a certificate by its verification code
+func (c *certificateController) VerifyCertificate(ctx echo.Context) error {
+	certificate, err := c.certificateUsecase.VerifyCertificate(ctx.Param("code"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Certificate is not valid",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Certificate is valid",
+			certificate,
+		),
+	)
+}
@@ -0,0 +1,598 @@
+package controllers
+
+import (
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CollectionController interface {
+	GetCollections(c echo.Context) error
+	GetCollection(c echo.Context) error
+	GetSharedCollection(c echo.Context) error
+	CreateCollection(c echo.Context) error
+	UpdateCollection(c echo.Context) error
+	DeleteCollection(c echo.Context) error
+	AddCollectionItem(c echo.Context) error
+	RemoveCollectionItem(c echo.Context) error
+	ReorderCollectionItems(c echo.Context) error
+	ShareCollection(c echo.Context) error
+	UnshareCollection(c echo.Context) error
+}
+
+type collectionController struct {
+	collectionUsecase usecase.CollectionUsecase
+	articleUsecase    usecase.ArticleUsecase
+}
+
+func NewCollectionController(collectionUsecase usecase.CollectionUsecase, articleUsecase usecase.ArticleUsecase) CollectionController {
+	return &collectionController{collectionUsecase, articleUsecase}
+}
+
+// Controller for get Collections of the logged in User
+func (c *collectionController) GetCollections(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	collections, count, err := c.collectionUsecase.GetCollections(uint(userId), page, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed fetching collections",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get collections",
+			collections,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for get a Collection with its Articles
+func (c *collectionController) GetCollection(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.GetCollection(uint(userId), uint(id))
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, collection.Articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get collection",
+			collection,
+		),
+	)
+}
+
+// Controller for get a Collection shared by its owner
+func (c *collectionController) GetSharedCollection(ctx echo.Context) error {
+	collection, err := c.collectionUsecase.GetSharedCollection(ctx.Param("token"))
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, collection.Articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to get collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get collection",
+			collection,
+		),
+	)
+}
+
+// Controller for create a Collection
+func (c *collectionController) CreateCollection(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.CollectionRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Collection name is not valid",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.CreateCollection(uint(userId), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed to create collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusCreated,
+		helpers.NewResponse(
+			http.StatusCreated,
+			"Successfully created collection",
+			collection,
+		),
+	)
+}
+
+// Controller for update a Collection
+func (c *collectionController) UpdateCollection(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.CollectionRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Collection name is not valid",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.UpdateCollection(uint(userId), uint(id), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to update collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully updated collection",
+			collection,
+		),
+	)
+}
+
+// Controller for delete a Collection
+func (c *collectionController) DeleteCollection(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	err = c.collectionUsecase.DeleteCollection(uint(userId), uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to delete collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully deleted collection",
+			nil,
+		),
+	)
+}
+
+// Controller for add an Article to a Collection
+func (c *collectionController) AddCollectionItem(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.CollectionItemRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Article ID is required",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.AddCollectionItem(uint(userId), uint(id), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to add article to collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article has been added to collection",
+			collection,
+		),
+	)
+}
+
+// Controller for remove an Article from a Collection
+func (c *collectionController) RemoveCollectionItem(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	articleId, err := strconv.Atoi(ctx.Param("article_id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.RemoveCollectionItem(uint(userId), uint(id), uint(articleId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to remove article from collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Article has been removed from collection",
+			collection,
+		),
+	)
+}
+
+// Controller for reorder Articles of a Collection
+func (c *collectionController) ReorderCollectionItems(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.CollectionOrderRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Article IDs are required",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.ReorderCollectionItems(uint(userId), uint(id), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to reorder collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully reordered collection",
+			collection,
+		),
+	)
+}
+
+// Controller for share a Collection through a public link
+func (c *collectionController) ShareCollection(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.ShareCollection(uint(userId), uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to share collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Collection has been shared",
+			collection,
+		),
+	)
+}
+
+// Controller for revoke the public link of a Collection
+func (c *collectionController) UnshareCollection(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get collection ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	collection, err := c.collectionUsecase.UnshareCollection(uint(userId), uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to unshare collection",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Collection is no longer shared",
+			collection,
+		),
+	)
+}
@@ -0,0 +1,433 @@
+package controllers
+
+import (
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/models"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommentController interface {
+	GetArticleComments(c echo.Context) error
+	CreateComment(c echo.Context) error
+	UpdateComment(c echo.Context) error
+	DeleteComment(c echo.Context) error
+	GetModerationQueue(c echo.Context) error
+	ModerateComment(c echo.Context) error
+	AdminDeleteComment(c echo.Context) error
+}
+
+type commentController struct {
+	commentUsecase usecase.CommentUsecase
+}
+
+func NewCommentController(commentUsecase usecase.CommentUsecase) CommentController {
+	return &commentController{commentUsecase}
+}
+
+// Controller for Get approved Comments of an Article with nested replies
+func (c *commentController) GetArticleComments(ctx echo.Context) error {
+	articleId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	comments, count, err := c.commentUsecase.GetArticleComments(uint(articleId), page, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching comments",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get comments",
+			comments,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for create Comment or reply on an Article
+func (c *commentController) CreateComment(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	articleId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.CommentRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Comment cannot be empty",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	comment, err := c.commentUsecase.CreateComment(uint(userId), uint(articleId), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to create comment",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusCreated,
+		helpers.NewResponse(
+			http.StatusCreated,
+			"Comment is waiting for moderation",
+			comment,
+		),
+	)
+}
+
+// Controller for update own Comment
+func (c *commentController) UpdateComment(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	articleId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	commentId, err := strconv.Atoi(ctx.Param("comment_id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get comment ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var req dtos.UpdateCommentRequest
+	ctx.Bind(&req)
+	if err := ctx.Validate(&req); err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Comment cannot be empty",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	comment, err := c.commentUsecase.UpdateComment(uint(userId), uint(articleId), uint(commentId), req)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to update comment",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Comment updated and waiting for moderation",
+			comment,
+		),
+	)
+}
+
+// Controller for delete own Comment
+func (c *commentController) DeleteComment(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	articleId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get article ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	commentId, err := strconv.Atoi(ctx.Param("comment_id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get comment ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	err = c.commentUsecase.DeleteComment(uint(userId), uint(articleId), uint(commentId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to delete comment",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponseMessage(
+			http.StatusOK,
+			"Comment deleted successfully",
+		),
+	)
+}
+
+// Controller for Get Comment moderation queue
+func (c *commentController) GetModerationQueue(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	comments, count, err := c.commentUsecase.GetModerationQueue(ctx.QueryParam("status"), page, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching comments",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get comments",
+			comments,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for approve or hide a Comment
+func (c *commentController) ModerateComment(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	commentId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get comment ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	var status string
+	switch ctx.Param("action") {
+	case "approve":
+		status = models.CommentApproved
+	case "hide":
+		status = models.CommentHidden
+	default:
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewResponseMessage(
+				http.StatusBadRequest,
+				"Action must be approve or hide",
+			),
+		)
+	}
+
+	comment, err := c.commentUsecase.ModerateComment(uint(commentId), status)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to moderate comment",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Comment moderated successfully",
+			comment,
+		),
+	)
+}
+
+// Controller for delete any Comment by admin
+func (c *commentController) AdminDeleteComment(ctx echo.Context) error {
+	_, err := m.IsAdmin(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Routes for Admin Only",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	commentId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get comment ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	err = c.commentUsecase.AdminDeleteComment(uint(commentId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to delete comment",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponseMessage(
+			http.StatusOK,
+			"Comment deleted successfully",
+		),
+	)
+}
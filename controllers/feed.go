@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/usecase"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FeedController interface {
+	GetRSSFeed(c echo.Context) error
+	GetAtomFeed(c echo.Context) error
+}
+
+type feedController struct {
+	feedUsecase usecase.FeedUsecase
+}
+
+func NewFeedController(feedUsecase usecase.FeedUsecase) FeedController {
+	return &feedController{feedUsecase}
+}
+
+// Controller for RSS 2.0 feed of published Article
+func (c *feedController) GetRSSFeed(ctx echo.Context) error {
+	feed, err := c.feedUsecase.GetRSSFeed(feedFilter(ctx), feedSelfURL(ctx))
+	if err != nil {
+		return feedError(ctx, err)
+	}
+
+	return writeFeed(ctx, feed)
+}
+
+// Controller for Atom feed of published Article
+func (c *feedController) GetAtomFeed(ctx echo.Context) error {
+	feed, err := c.feedUsecase.GetAtomFeed(feedFilter(ctx), feedSelfURL(ctx))
+	if err != nil {
+		return feedError(ctx, err)
+	}
+
+	return writeFeed(ctx, feed)
+}
+
+func feedFilter(ctx echo.Context) dtos.ArticleFilter {
+	return dtos.ArticleFilter{
+		Category: ctx.QueryParam("category"),
+		Tag:      ctx.QueryParam("tag"),
+	}
+}
+
+func feedSelfURL(ctx echo.Context) string {
+	return ctx.Scheme() + "://" + ctx.Request().Host + ctx.Request().URL.RequestURI()
+}
+
+func feedError(ctx echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	if strings.HasSuffix(err.Error(), "not found") {
+		status = http.StatusNotFound
+	}
+
+	return ctx.JSON(
+		status,
+		helpers.NewErrorResponse(
+			status,
+			"Failed to get feed",
+			helpers.GetErrorData(err),
+		),
+	)
+}
+
+// Write feed body, answer 304 when the client copy is still fresh
+func writeFeed(ctx echo.Context, feed dtos.FeedResponse) error {
+	header := ctx.Response().Header()
+	header.Set("ETag", feed.ETag)
+	header.Set("Cache-Control", "public, max-age=300")
+	if !feed.LastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, feed.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(ctx.Request(), feed) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	return ctx.Blob(http.StatusOK, feed.ContentType, feed.Body)
+}
+
+// If-None-Match takes precedence over If-Modified-Since as in RFC 7232
+func notModified(req *http.Request, feed dtos.FeedResponse) bool {
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		for _, etag := range strings.Split(match, ",") {
+			etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+			if etag == feed.ETag || etag == "*" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	since, err := http.ParseTime(req.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil || feed.LastModified.IsZero() {
+		return false
+	}
+
+	return !feed.LastModified.Truncate(time.Second).After(since)
+}
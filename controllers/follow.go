@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FollowController interface {
+	FollowAuthor(c echo.Context) error
+	UnfollowAuthor(c echo.Context) error
+	FollowCategory(c echo.Context) error
+	UnfollowCategory(c echo.Context) error
+	GetFollowing(c echo.Context) error
+}
+
+type followController struct {
+	followUsecase usecase.FollowUsecase
+}
+
+func NewFollowController(followUsecase usecase.FollowUsecase) FollowController {
+	return &followController{followUsecase}
+}
+
+// Controller for follow an Author
+func (c *followController) FollowAuthor(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	follow, err := c.followUsecase.FollowAuthor(uint(userId), ctx.Param("username"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to follow author",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully followed author",
+			follow,
+		),
+	)
+}
+
+// Controller for unfollow an Author
+func (c *followController) UnfollowAuthor(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	follow, err := c.followUsecase.UnfollowAuthor(uint(userId), ctx.Param("username"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to unfollow author",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully unfollowed author",
+			follow,
+		),
+	)
+}
+
+// Controller for follow a Category
+func (c *followController) FollowCategory(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	follow, err := c.followUsecase.FollowCategory(uint(userId), ctx.Param("slug"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to follow category",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully followed category",
+			follow,
+		),
+	)
+}
+
+// Controller for unfollow a Category
+func (c *followController) UnfollowCategory(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	follow, err := c.followUsecase.UnfollowCategory(uint(userId), ctx.Param("slug"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to unfollow category",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully unfollowed category",
+			follow,
+		),
+	)
+}
+
+// Controller for get Authors and Categories followed by the logged in User
+func (c *followController) GetFollowing(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	following, err := c.followUsecase.GetFollowing(uint(userId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching following",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get following",
+			following,
+		),
+	)
+}
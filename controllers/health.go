@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	"go_bedu/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthController interface {
+	GetHealth(c echo.Context) error
+}
+
+type healthController struct {
+	healthUsecase usecase.HealthUsecase
+}
+
+func NewHealthController(healthUsecase usecase.HealthUsecase) HealthController {
+	return &healthController{healthUsecase}
+}
+
+// Controller for health check with scheduler state
+func (c *healthController) GetHealth(ctx echo.Context) error {
+	health, healthy := c.healthUsecase.GetHealth()
+	if !healthy {
+		return ctx.JSON(
+			http.StatusServiceUnavailable,
+			helpers.NewResponse(
+				http.StatusServiceUnavailable,
+				"Service is unhealthy",
+				health,
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Service is healthy",
+			health,
+		),
+	)
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	m "go_bedu/middlewares"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationController interface {
+	GetNotifications(c echo.Context) error
+	MarkNotificationRead(c echo.Context) error
+	MarkAllNotificationsRead(c echo.Context) error
+}
+
+type notificationController struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+func NewNotificationController(notificationUsecase usecase.NotificationUsecase) NotificationController {
+	return &notificationController{notificationUsecase}
+}
+
+// Controller for get notifications of the logged in User
+func (c *notificationController) GetNotifications(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	notifications, count, err := c.notificationUsecase.GetNotifications(uint(userId), ctx.QueryParam("status"), page, limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed fetching notifications",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get notifications",
+			notifications,
+			page,
+			limit,
+			count,
+		),
+	)
+}
+
+// Controller for mark a notification as read
+func (c *notificationController) MarkNotificationRead(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to get notification ID",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	err = c.notificationUsecase.MarkNotificationRead(uint(userId), uint(id))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed to mark notification as read",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Notification has been marked as read",
+			nil,
+		),
+	)
+}
+
+// Controller for mark all notifications of the logged in User as read
+func (c *notificationController) MarkAllNotificationsRead(ctx echo.Context) error {
+	userId, err := m.IsUser(ctx)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusUnauthorized,
+			helpers.NewErrorResponse(
+				http.StatusUnauthorized,
+				"Please login for access",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	err = c.notificationUsecase.MarkAllNotificationsRead(uint(userId))
+	if err != nil {
+		return ctx.JSON(
+			http.StatusBadRequest,
+			helpers.NewErrorResponse(
+				http.StatusBadRequest,
+				"Failed to mark notifications as read",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"All notifications have been marked as read",
+			nil,
+		),
+	)
+}
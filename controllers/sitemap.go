@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"go_bedu/usecase"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SitemapController interface {
+	GetSitemap(c echo.Context) error
+}
+
+type sitemapController struct {
+	sitemapUsecase usecase.SitemapUsecase
+}
+
+func NewSitemapController(sitemapUsecase usecase.SitemapUsecase) SitemapController {
+	return &sitemapController{sitemapUsecase}
+}
+
+// Controller for XML sitemap, a sitemap index is returned when URLs exceed one file
+func (c *sitemapController) GetSitemap(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 0 {
+		page = 0
+	}
+
+	baseURL := ctx.Scheme() + "://" + ctx.Request().Host + ctx.Request().URL.Path
+
+	sitemap, err := c.sitemapUsecase.GetSitemap(page, baseURL)
+	if err != nil {
+		return feedError(ctx, err)
+	}
+
+	return writeFeed(ctx, sitemap)
+}
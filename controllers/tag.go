@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"go_bedu/helpers"
+	"go_bedu/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TagController interface {
+	GetTagCloud(c echo.Context) error
+	GetArticlesByTag(c echo.Context) error
+}
+
+type tagController struct {
+	tagUsecase     usecase.TagUsecase
+	articleUsecase usecase.ArticleUsecase
+}
+
+func NewTagController(tagUsecase usecase.TagUsecase, articleUsecase usecase.ArticleUsecase) TagController {
+	return &tagController{tagUsecase, articleUsecase}
+}
+
+// Controller for Get Tag Cloud with usage count
+func (c *tagController) GetTagCloud(ctx echo.Context) error {
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 50
+	}
+
+	tags, err := c.tagUsecase.GetTagCloud(limit)
+	if err != nil {
+		return ctx.JSON(
+			http.StatusInternalServerError,
+			helpers.NewErrorResponse(
+				http.StatusInternalServerError,
+				"Failed fetching tag cloud",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewResponse(
+			http.StatusOK,
+			"Successfully get tag cloud",
+			tags,
+		),
+	)
+}
+
+// Controller for Get Articles by Tag slug with pagination
+func (c *tagController) GetArticlesByTag(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	articles, count, err := c.tagUsecase.GetArticlesByTag(ctx.Param("slug"), page, limit)
+	if err == nil {
+		err = markLikedByMe(ctx, c.articleUsecase, articles)
+	}
+	if err != nil {
+		return ctx.JSON(
+			http.StatusNotFound,
+			helpers.NewErrorResponse(
+				http.StatusNotFound,
+				"Failed fetching tag articles",
+				helpers.GetErrorData(err),
+			),
+		)
+	}
+
+	return ctx.JSON(
+		http.StatusOK,
+		helpers.NewPaginationResponse(
+			http.StatusOK,
+			"Successfully get tag articles",
+			articles,
+			page,
+			limit,
+			count,
+		),
+	)
+}
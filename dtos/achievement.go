@@ -0,0 +1,67 @@
+package dtos
+
+import "time"
+
+type AchievementSettingsRequest struct {
+	Timezone          string `json:"timezone" form:"timezone" validate:"required" example:"Asia/Jakarta"`
+	LeaderboardOptOut bool   `json:"leaderboard_opt_out" form:"leaderboard_opt_out" example:"false"`
+}
+
+type BadgeRequest struct {
+	Code        string `json:"code" form:"code" validate:"required,max=50" example:"bookworm"`
+	Name        string `json:"name" form:"name" validate:"required" example:"Bookworm"`
+	Description string `json:"description" form:"description" example:"Finish reading 25 articles"`
+	Icon        string `json:"icon" form:"icon" example:"https://res.cloudinary.com/dvexlihfn/image/upload/v1686546113/go_bedu/bookworm.png"`
+	Metric      string `json:"metric" form:"metric" validate:"required,oneof=points articles_read articles_liked quizzes_passed reading_streak" example:"articles_read"`
+	Threshold   int    `json:"threshold" form:"threshold" validate:"required,min=1" example:"25"`
+}
+
+type BadgeResponse struct {
+	BadgeID     uint       `json:"badge_id" example:"2"`
+	Code        string     `json:"code" example:"bookworm"`
+	Name        string     `json:"name" example:"Bookworm"`
+	Description string     `json:"description" example:"Finish reading 25 articles"`
+	Icon        string     `json:"icon" example:"https://res.cloudinary.com/dvexlihfn/image/upload/v1686546113/go_bedu/bookworm.png"`
+	Metric      string     `json:"metric" example:"articles_read"`
+	Threshold   int        `json:"threshold" example:"25"`
+	Progress    *int       `json:"progress,omitempty" example:"12"`
+	Earned      *bool      `json:"earned,omitempty" example:"false"`
+	AwardedAt   *time.Time `json:"awarded_at,omitempty" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type PointTransactionResponse struct {
+	Source    string    `json:"source" example:"read_article"`
+	SourceID  uint      `json:"source_id" example:"1"`
+	Points    int       `json:"points" example:"10"`
+	CreatedAt time.Time `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type AchievementResponse struct {
+	Points            int                        `json:"points" example:"120"`
+	WeeklyPoints      int                        `json:"weekly_points" example:"35"`
+	ArticlesRead      int                        `json:"articles_read" example:"6"`
+	ArticlesLiked     int                        `json:"articles_liked" example:"4"`
+	QuizzesPassed     int                        `json:"quizzes_passed" example:"2"`
+	CurrentStreak     int                        `json:"current_streak" example:"3"`
+	LongestStreak     int                        `json:"longest_streak" example:"5"`
+	Timezone          string                     `json:"timezone" example:"Asia/Jakarta"`
+	LeaderboardOptOut bool                       `json:"leaderboard_opt_out" example:"false"`
+	Badges            []BadgeResponse            `json:"badges"`
+	RecentPoints      []PointTransactionResponse `json:"recent_points"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank         int    `json:"rank" example:"1"`
+	Username     string `json:"username" example:"r4ha"`
+	FullName     string `json:"fullname" example:"Rahadina Budiman Sundara"`
+	PhotoProfile string `json:"photo_profile" example:"https://res.cloudinary.com/dvexlihfn/image/upload/v1686546113/go_bedu/mlc5oequ9xjvtm0w8kqb.jpg"`
+	Points       int    `json:"points" example:"85"`
+}
+
+type LeaderboardResponse struct {
+	WeekStart time.Time                  `json:"week_start" example:"2023-05-15T00:00:00+07:00"`
+	WeekEnd   time.Time                  `json:"week_end" example:"2023-05-22T00:00:00+07:00"`
+	Entries   []LeaderboardEntryResponse `json:"entries"`
+	MyRank    int                        `json:"my_rank,omitempty" example:"7"`
+	MyPoints  int                        `json:"my_points,omitempty" example:"35"`
+}
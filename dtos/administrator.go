@@ -34,6 +34,20 @@ type UpdateAdminRequest struct {
 	Username string `json:"username" form:"username" validate:"required" example:"r4ha"`
 	Email    string `json:"email" form:"email" validate:"required,email" example:"me@r4ha.com"`
 	Role     string `json:"role" form:"role" gorm:"type:enum('Admin', 'Super Admin');default:'Admin'; not-null" example:"Admin"`
+	// Author profile fields are kept when left out, send an empty string to clear them.
+	// Links are shown on the public author page so only http and https links, or an empty string, are accepted
+	Bio       *string `json:"bio" form:"bio" validate:"omitempty,max=1000" example:"Pelatih kebugaran dan penulis artikel gaya hidup sehat"`
+	Website   *string `json:"website" form:"website" validate:"omitempty,eq=|http_url" example:"https://r4ha.com"`
+	Twitter   *string `json:"twitter" form:"twitter" validate:"omitempty,eq=|http_url" example:"https://twitter.com/r4ha"`
+	Instagram *string `json:"instagram" form:"instagram" validate:"omitempty,eq=|http_url" example:"https://instagram.com/r4ha"`
+	LinkedIn  *string `json:"linkedin" form:"linkedin" validate:"omitempty,eq=|http_url" example:"https://linkedin.com/in/r4ha"`
+}
+
+type SocialLinksResponse struct {
+	Website   string `json:"website" example:"https://r4ha.com"`
+	Twitter   string `json:"twitter" example:"https://twitter.com/r4ha"`
+	Instagram string `json:"instagram" example:"https://instagram.com/r4ha"`
+	LinkedIn  string `json:"linkedin" example:"https://linkedin.com/in/r4ha"`
 }
 
 type DeleteAdminRequest struct {
@@ -49,18 +63,22 @@ type RegisterAdminResponse struct {
 }
 
 type UpdateAdminResponse struct {
-	Nama     string `json:"nama" form:"nama" example:"Rahadina Budiman Sundara"`
-	Username string `json:"username" form:"username" validate:"required" example:"r4ha"`
-	Email    string `json:"email" form:"email" example:"me@r4ha.com"`
-	Role     string `json:"role" form:"role" example:"Admin"`
+	Nama        string              `json:"nama" form:"nama" example:"Rahadina Budiman Sundara"`
+	Username    string              `json:"username" form:"username" validate:"required" example:"r4ha"`
+	Email       string              `json:"email" form:"email" example:"me@r4ha.com"`
+	Role        string              `json:"role" form:"role" example:"Admin"`
+	Bio         string              `json:"bio" example:"Pelatih kebugaran dan penulis artikel gaya hidup sehat"`
+	SocialLinks SocialLinksResponse `json:"social_links"`
 }
 
 type AdminProfileResponse struct {
-	ID       uint   `json:"id" form:"id" example:"1"`
-	Username string `json:"username" form:"username" validate:"required" example:"r4ha"`
-	Nama     string `json:"nama" form:"nama" example:"Rahadina Budiman Sundara"`
-	Email    string `json:"email" form:"email" example:"me@r4ha.com"`
-	Role     string `json:"role" form:"role" example:"Admin"`
+	ID          uint                `json:"id" form:"id" example:"1"`
+	Username    string              `json:"username" form:"username" validate:"required" example:"r4ha"`
+	Nama        string              `json:"nama" form:"nama" example:"Rahadina Budiman Sundara"`
+	Email       string              `json:"email" form:"email" example:"me@r4ha.com"`
+	Role        string              `json:"role" form:"role" example:"Admin"`
+	Bio         string              `json:"bio" example:"Pelatih kebugaran dan penulis artikel gaya hidup sehat"`
+	SocialLinks SocialLinksResponse `json:"social_links"`
 }
 
 type ChangePasswordAdminRequest struct {
@@ -1,25 +1,71 @@
 package dtos
 
-import "time"
+import (
+	"go_bedu/helpers"
+	"time"
+)
+
+// Optional filters for article listing taken from query params
+type ArticleFilter struct {
+	Category string `query:"category" example:"kebugaran"`
+	Tag      string `query:"tag" example:"diet-sehat"`
+	Status   string `query:"status" example:"draft"`
+	// Set from the token on the bookmark listing, never from query params
+	BookmarkedBy uint `query:"-"`
+	// Set from the author profile path, never from query params
+	AuthorID uint `query:"-"`
+}
+
+type ArticleScheduleRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" form:"scheduled_at" validate:"required" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type ArticleStatusRequest struct {
+	Status string `json:"status" form:"status" validate:"required,oneof=draft in_review published archived" example:"published"`
+}
+
+// Markdown description rendered without saving the article
+type ArticlePreviewRequest struct {
+	Description string `json:"description" form:"description" validate:"required" example:"## Pemanasan\n\nisi artikel"`
+}
+
+type ArticlePreviewResponse struct {
+	DescriptionHTML string             `json:"description_html" example:"<h2 id=\"pemanasan\">Pemanasan</h2>\n<p>isi artikel</p>"`
+	TableOfContents []helpers.TOCEntry `json:"table_of_contents"`
+}
 
 type CreateArticlesRequest struct {
-	AdministratorID uint   `json:"administrator_id" form:"administrator_id" example:"1"`
-	Thumbnail       string `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
-	Title           string `json:"title" form:"title" example:"judulArticle"`
-	Abstract        string `json:"abstract" form:"abstract" example:"abstract/pengantar"`
-	Description     string `json:"description" form:"description" example:"isi artikel"`
-	Image           string `json:"image" form:"image" example:"link image"`
-	Label           string `json:"label" form:"label" example:"kebugaran"`
+	AdministratorID uint     `json:"administrator_id" form:"administrator_id" example:"1"`
+	CategoryID      uint     `json:"category_id" form:"category_id" example:"1"`
+	Thumbnail       string   `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
+	Title           string   `json:"title" form:"title" example:"judulArticle"`
+	Abstract        string   `json:"abstract" form:"abstract" example:"abstract/pengantar"`
+	Description     string   `json:"description" form:"description" example:"isi artikel"`
+	Image           string   `json:"image" form:"image" example:"link image"`
+	Label           string   `json:"label" form:"label" example:"kebugaran"`
+	Tags            []string `json:"tags" form:"tags" example:"diet sehat,olahraga"`
+	MetaTitle       string   `json:"meta_title" form:"meta_title" example:"Judul Artikel untuk Mesin Pencari"`
+	MetaDescription string   `json:"meta_description" form:"meta_description" example:"Ringkasan artikel untuk mesin pencari"`
+	CanonicalURL    string   `json:"canonical_url" form:"canonical_url" validate:"omitempty,url" example:"https://bedu.keyzex.com/#/article/judularticle"`
+	OGImage         string   `json:"og_image" form:"og_image" example:"gambar1.jpg"`
+	Status          string   `json:"status" form:"status" validate:"omitempty,oneof=draft in_review published" example:"draft"`
 }
 
 type UpdateArticlesRequest struct {
-	AdministratorID uint   `json:"administrator_id" form:"administrator_id" example:"1"`
-	Thumbnail       string `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
-	Title           string `json:"title" form:"title" example:"judulArticle" validate:"required"`
-	Abstract        string `json:"abstract" form:"abstract" example:"abstract/pengantar" validate:"required"`
-	Description     string `json:"description" form:"description" example:"isi artikel" validate:"required"`
-	Image           string `json:"image" form:"image" example:"gambar2.jpg"`
-	Label           string `json:"label" form:"label" example:"kebugaran" validate:"required"`
+	AdministratorID uint     `json:"administrator_id" form:"administrator_id" example:"1"`
+	CategoryID      uint     `json:"category_id" form:"category_id" example:"1"`
+	Thumbnail       string   `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
+	Title           string   `json:"title" form:"title" example:"judulArticle" validate:"required"`
+	Abstract        string   `json:"abstract" form:"abstract" example:"abstract/pengantar" validate:"required"`
+	Description     string   `json:"description" form:"description" example:"isi artikel" validate:"required"`
+	Image           string   `json:"image" form:"image" example:"gambar2.jpg"`
+	Label           string   `json:"label" form:"label" example:"kebugaran"`
+	Tags            []string `json:"tags" form:"tags" example:"diet sehat,olahraga"`
+	// SEO fields are kept when left out, send an empty string to clear them
+	MetaTitle       *string `json:"meta_title" form:"meta_title" example:"Judul Artikel untuk Mesin Pencari"`
+	MetaDescription *string `json:"meta_description" form:"meta_description" example:"Ringkasan artikel untuk mesin pencari"`
+	CanonicalURL    *string `json:"canonical_url" form:"canonical_url" validate:"omitempty,eq=|url" example:"https://bedu.keyzex.com/#/article/judularticle"`
+	OGImage         *string `json:"og_image" form:"og_image" example:"gambar1.jpg"`
 }
 
 type CreateArticlesResponse struct {
@@ -42,14 +88,44 @@ type UpdateArticleResponse struct {
 }
 
 type ArticleDetailResponse struct {
-	ArticleID   uint      `json:"article_id" example:"1"`
-	Thumbnail   string    `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
-	Title       string    `json:"title" form:"title" example:"judulArticle"`
-	Abstract    string    `json:"abstract" form:"abstract" example:"abstract/pengantar"`
-	Image       string    `json:"image" form:"image" example:"gambar2.jpg"`
-	Description string    `json:"description" form:"description" example:"isi artikel"`
-	Label       string    `json:"label" form:"label" example:"kebugaran"`
-	Slug        string    `json:"slug" form:"slug" example:"judularticle"`
-	CreatedAt   time.Time `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+	ArticleID           uint                     `json:"article_id" example:"1"`
+	Thumbnail           string                   `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
+	Title               string                   `json:"title" form:"title" example:"judulArticle"`
+	Abstract            string                   `json:"abstract" form:"abstract" example:"abstract/pengantar"`
+	Image               string                   `json:"image" form:"image" example:"gambar2.jpg"`
+	Description         string                   `json:"description" form:"description" example:"isi artikel"`
+	DescriptionMarkdown string                   `json:"description_markdown" example:"## Pemanasan\n\nisi artikel"`
+	DescriptionHTML     string                   `json:"description_html" example:"<h2 id=\"pemanasan\">Pemanasan</h2>\n<p>isi artikel</p>"`
+	TableOfContents     []helpers.TOCEntry       `json:"table_of_contents"`
+	WordCount           int                      `json:"word_count" example:"850"`
+	ReadingTime         int                      `json:"reading_time" example:"5"`
+	Label               string                   `json:"label" form:"label" example:"kebugaran"`
+	Category            *ArticleCategoryResponse `json:"category,omitempty"`
+	Tags                []ArticleTagResponse     `json:"tags"`
+	CommentCount        int                      `json:"comment_count" example:"3"`
+	LikeCount           int                      `json:"like_count" example:"12"`
+	LikedByMe           *bool                    `json:"liked_by_me,omitempty" example:"true"`
+	Views               int                      `json:"views" example:"120"`
+	Slug                string                   `json:"slug" form:"slug" example:"judularticle"`
+	MetaTitle           string                   `json:"meta_title" example:"Judul Artikel untuk Mesin Pencari"`
+	MetaDescription     string                   `json:"meta_description" example:"Ringkasan artikel untuk mesin pencari"`
+	CanonicalURL        string                   `json:"canonical_url" example:"https://bedu.keyzex.com/#/article/judularticle"`
+	OGImage             string                   `json:"og_image" example:"gambar1.jpg"`
+	Status              string                   `json:"status" example:"published"`
+	PublishedAt         *time.Time               `json:"published_at" example:"2023-05-17T15:07:16.504+07:00"`
+	ScheduledAt         *time.Time               `json:"scheduled_at" example:"2023-05-17T15:07:16.504+07:00"`
+	CreatedAt           time.Time                `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+	UpdatedAt           time.Time                `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+	Relevance           float64                  `json:"relevance,omitempty" example:"1.5"`
+	Position            int                      `json:"position,omitempty" example:"1"`
+	Score               float64                  `json:"score,omitempty" example:"42.5"`
+	Highlight           *ArticleHighlight        `json:"highlight,omitempty"`
+}
+
+// Highlighted snippets returned by search, matched terms are wrapped with <mark>
+type ArticleHighlight struct {
+	Title       string `json:"title,omitempty" example:"<mark>Kebugaran</mark> Tubuh"`
+	Abstract    string `json:"abstract,omitempty" example:"...menjaga <mark>kebugaran</mark> tubuh..."`
+	Description string `json:"description,omitempty" example:"...latihan <mark>kebugaran</mark> rutin..."`
+	Label       string `json:"label,omitempty" example:"<mark>kebugaran</mark>"`
 }
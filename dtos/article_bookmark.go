@@ -0,0 +1,6 @@
+package dtos
+
+type ArticleBookmarkResponse struct {
+	ArticleID  uint `json:"article_id" example:"1"`
+	Bookmarked bool `json:"bookmarked" example:"true"`
+}
@@ -4,3 +4,9 @@ type ArticleLiked struct {
 	ArticleID uint `json:"article_id" form:"article_id"`
 	UserID    uint `json:"user_id" form:"user_id"`
 }
+
+type ArticleLikeResponse struct {
+	ArticleID uint `json:"article_id" example:"1"`
+	Liked     bool `json:"liked" example:"true"`
+	LikeCount int  `json:"like_count" example:"12"`
+}
@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"go_bedu/helpers"
+	"time"
+)
+
+type ArticleRevisionResponse struct {
+	RevisionID        uint      `json:"revision_id" example:"1"`
+	ArticleID         uint      `json:"article_id" example:"1"`
+	Revision          int       `json:"revision" example:"2"`
+	AdministratorID   uint      `json:"administrator_id" example:"1"`
+	AdministratorName string    `json:"administrator_name" example:"Rahadina Budiman Sundara"`
+	CategoryID        *uint     `json:"category_id" example:"1"`
+	Thumbnail         string    `json:"thumbnail" example:"gambar1.jpg"`
+	Title             string    `json:"title" example:"judulArticle"`
+	Abstract          string    `json:"abstract" example:"abstract/pengantar"`
+	Description       string    `json:"description" example:"isi artikel"`
+	Image             string    `json:"image" example:"gambar2.jpg"`
+	Label             string    `json:"label" example:"kebugaran"`
+	Slug              string    `json:"slug" example:"judularticle"`
+	MetaTitle         string    `json:"meta_title" example:"Judul Artikel untuk Mesin Pencari"`
+	MetaDescription   string    `json:"meta_description" example:"Ringkasan artikel untuk mesin pencari"`
+	CanonicalURL      string    `json:"canonical_url" example:"https://bedu.keyzex.com/#/article/judularticle"`
+	OGImage           string    `json:"og_image" example:"gambar1.jpg"`
+	Tags              []string  `json:"tags" example:"diet sehat,olahraga"`
+	CreatedAt         time.Time `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+// Changed field between two revisions, long text fields also have line level diff
+type ArticleFieldDiff struct {
+	Field string             `json:"field" example:"description"`
+	From  string             `json:"from" example:"isi artikel"`
+	To    string             `json:"to" example:"isi artikel baru"`
+	Lines []helpers.DiffLine `json:"lines,omitempty"`
+}
+
+type ArticleRevisionDiffResponse struct {
+	ArticleID uint               `json:"article_id" example:"1"`
+	From      int                `json:"from" example:"1"`
+	To        int                `json:"to" example:"2"`
+	Changes   []ArticleFieldDiff `json:"changes"`
+}
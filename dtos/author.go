@@ -0,0 +1,14 @@
+package dtos
+
+// Public profile of an administrator, private account data is left out
+type AuthorResponse struct {
+	Username      string                  `json:"username" example:"r4ha"`
+	Name          string                  `json:"name" example:"Rahadina Budiman Sundara"`
+	PhotoProfile  string                  `json:"photo_profile" example:"https://res.cloudinary.com/dvexlihfn/image/upload/v1686546113/go_bedu/mlc5oequ9xjvtm0w8kqb.jpg"`
+	Bio           string                  `json:"bio" example:"Pelatih kebugaran dan penulis artikel gaya hidup sehat"`
+	SocialLinks   SocialLinksResponse     `json:"social_links"`
+	FollowerCount int                     `json:"follower_count" example:"12"`
+	FollowedByMe  *bool                   `json:"followed_by_me,omitempty" example:"false"`
+	ArticleCount  int                     `json:"article_count" example:"8"`
+	Articles      []ArticleDetailResponse `json:"articles"`
+}
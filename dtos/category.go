@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+type CategoryRequest struct {
+	Name        string `json:"name" form:"name" validate:"required" example:"Kebugaran"`
+	Description string `json:"description" form:"description" example:"Artikel seputar kebugaran tubuh"`
+	Icon        string `json:"icon" form:"icon" example:"https://res.cloudinary.com/icon.png"`
+}
+
+type CategoryResponse struct {
+	CategoryID   uint      `json:"category_id" example:"1"`
+	Name         string    `json:"name" example:"Kebugaran"`
+	Slug         string    `json:"slug" example:"kebugaran"`
+	Description  string    `json:"description" example:"Artikel seputar kebugaran tubuh"`
+	Icon         string    `json:"icon" example:"https://res.cloudinary.com/icon.png"`
+	ArticleCount int       `json:"article_count" example:"10"`
+	CreatedAt    time.Time `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+// Short category info embedded in article responses
+type ArticleCategoryResponse struct {
+	CategoryID uint   `json:"category_id" example:"1"`
+	Name       string `json:"name" example:"Kebugaran"`
+	Slug       string `json:"slug" example:"kebugaran"`
+	Icon       string `json:"icon" example:"https://res.cloudinary.com/icon.png"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+type CertificateResponse struct {
+	CertificateID    uint      `json:"certificate_id" example:"1"`
+	CourseID         uint      `json:"course_id" example:"1"`
+	CourseTitle      string    `json:"course_title" example:"Hidup Sehat untuk Pemula"`
+	FullName         string    `json:"fullname" example:"Budi Santoso"`
+	VerificationCode string    `json:"verification_code" example:"9F2C4A7B1E6D3058"`
+	FileURL          string    `json:"file_url" example:"/public/certificates/9F2C4A7B1E6D3058.pdf"`
+	IssuedAt         time.Time `json:"issued_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type CertificateVerifyResponse struct {
+	Valid            bool      `json:"valid" example:"true"`
+	VerificationCode string    `json:"verification_code" example:"9F2C4A7B1E6D3058"`
+	FullName         string    `json:"fullname" example:"Budi Santoso"`
+	CourseTitle      string    `json:"course_title" example:"Hidup Sehat untuk Pemula"`
+	IssuedAt         time.Time `json:"issued_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
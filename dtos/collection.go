@@ -0,0 +1,30 @@
+package dtos
+
+import "time"
+
+type CollectionRequest struct {
+	Name        string `json:"name" form:"name" validate:"required,max=100" example:"Diet"`
+	Description string `json:"description" form:"description" example:"Artikel untuk program diet"`
+}
+
+type CollectionItemRequest struct {
+	ArticleID uint `json:"article_id" form:"article_id" validate:"required" example:"1"`
+}
+
+// Article IDs of a collection in the new order, items not listed keep their position after the listed ones
+type CollectionOrderRequest struct {
+	ArticleIDs []uint `json:"article_ids" form:"article_ids" validate:"required,min=1" example:"3,1,2"`
+}
+
+type CollectionResponse struct {
+	CollectionID uint                    `json:"collection_id" example:"1"`
+	Name         string                  `json:"name" example:"Diet"`
+	Description  string                  `json:"description" example:"Artikel untuk program diet"`
+	ItemCount    int                     `json:"item_count" example:"3"`
+	Shared       bool                    `json:"shared" example:"true"`
+	ShareURL     string                  `json:"share_url,omitempty" example:"https://bedu.keyzex.com/#/collection/9f86d081884c7d659a2feaa0c55ad015"`
+	Owner        string                  `json:"owner,omitempty" example:"r4ha"`
+	Articles     []ArticleDetailResponse `json:"articles,omitempty"`
+	CreatedAt    time.Time               `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+	UpdatedAt    time.Time               `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
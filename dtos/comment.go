@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+type CommentRequest struct {
+	Body     string `json:"body" form:"body" validate:"required" example:"Artikel yang sangat bermanfaat"`
+	ParentID *uint  `json:"parent_id" form:"parent_id" example:"1"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" form:"body" validate:"required" example:"Artikel yang sangat bermanfaat"`
+}
+
+type CommentResponse struct {
+	CommentID    uint              `json:"comment_id" example:"1"`
+	ArticleID    uint              `json:"article_id" example:"1"`
+	ArticleTitle string            `json:"article_title,omitempty" example:"judulArticle"`
+	ParentID     *uint             `json:"parent_id" example:"1"`
+	UserID       uint              `json:"user_id" example:"1"`
+	Username     string            `json:"username" example:"r4ha"`
+	FullName     string            `json:"fullname" example:"Rahadina Budiman Sundara"`
+	PhotoProfile string            `json:"photo_profile" example:"https://res.cloudinary.com/profile.jpg"`
+	Body         string            `json:"body" example:"Artikel yang sangat bermanfaat"`
+	Status       string            `json:"status" example:"Approved"`
+	Replies      []CommentResponse `json:"replies"`
+	CreatedAt    time.Time         `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+	UpdatedAt    time.Time         `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
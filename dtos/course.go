@@ -0,0 +1,54 @@
+package dtos
+
+import "time"
+
+type CourseRequest struct {
+	Title       string                `json:"title" form:"title" validate:"required" example:"Kebugaran untuk Pemula"`
+	Description string                `json:"description" form:"description" example:"10 pelajaran untuk memulai olahraga"`
+	Thumbnail   string                `json:"thumbnail" form:"thumbnail" example:"gambar1.jpg"`
+	Published   bool                  `json:"published" form:"published" example:"true"`
+	Lessons     []CourseLessonRequest `json:"lessons" form:"lessons" validate:"required,min=1,dive"`
+}
+
+// Lessons follow the order of the request
+type CourseLessonRequest struct {
+	ArticleID   uint `json:"article_id" form:"article_id" validate:"required" example:"1"`
+	RequireQuiz bool `json:"require_quiz" form:"require_quiz" example:"false"`
+}
+
+type CourseResponse struct {
+	CourseID    uint                    `json:"course_id" example:"1"`
+	Title       string                  `json:"title" example:"Kebugaran untuk Pemula"`
+	Slug        string                  `json:"slug" example:"kebugaran-untuk-pemula"`
+	Description string                  `json:"description" example:"10 pelajaran untuk memulai olahraga"`
+	Thumbnail   string                  `json:"thumbnail" example:"gambar1.jpg"`
+	Published   bool                    `json:"published" example:"true"`
+	LessonCount int                     `json:"lesson_count" example:"10"`
+	Progress    *CourseProgressResponse `json:"progress,omitempty"`
+	Lessons     []CourseLessonResponse  `json:"lessons,omitempty"`
+	CreatedAt   time.Time               `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+	UpdatedAt   time.Time               `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type CourseLessonResponse struct {
+	Position    int        `json:"position" example:"1"`
+	ArticleID   uint       `json:"article_id" example:"1"`
+	Title       string     `json:"title" example:"judulArticle"`
+	Slug        string     `json:"slug" example:"judularticle"`
+	Thumbnail   string     `json:"thumbnail" example:"gambar1.jpg"`
+	ReadingTime int        `json:"reading_time" example:"5"`
+	Status      string     `json:"status,omitempty" example:"published"`
+	RequireQuiz bool       `json:"require_quiz" example:"false"`
+	Completed   bool       `json:"completed" example:"true"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+// Progress of an enrolled user, percentage of completed lessons
+type CourseProgressResponse struct {
+	CompletedLessons int        `json:"completed_lessons" example:"4"`
+	TotalLessons     int        `json:"total_lessons" example:"10"`
+	Percentage       int        `json:"percentage" example:"40"`
+	NextArticleID    uint       `json:"next_article_id,omitempty" example:"5"`
+	EnrolledAt       time.Time  `json:"enrolled_at" example:"2023-05-17T15:07:16.504+07:00"`
+	CompletedAt      *time.Time `json:"completed_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
@@ -0,0 +1,30 @@
+package dtos
+
+import "time"
+
+type FollowResponse struct {
+	Following     bool `json:"following" example:"true"`
+	FollowerCount int  `json:"follower_count" example:"12"`
+}
+
+type FollowedAuthorResponse struct {
+	Username      string    `json:"username" example:"r4ha"`
+	Name          string    `json:"name" example:"Rahadina Budiman Sundara"`
+	PhotoProfile  string    `json:"photo_profile" example:"https://res.cloudinary.com/dvexlihfn/image/upload/v1686546113/go_bedu/mlc5oequ9xjvtm0w8kqb.jpg"`
+	FollowerCount int       `json:"follower_count" example:"12"`
+	FollowedAt    time.Time `json:"followed_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type FollowedCategoryResponse struct {
+	CategoryID    uint      `json:"category_id" example:"1"`
+	Name          string    `json:"name" example:"Kebugaran"`
+	Slug          string    `json:"slug" example:"kebugaran"`
+	Icon          string    `json:"icon" example:"https://res.cloudinary.com/icon.png"`
+	FollowerCount int       `json:"follower_count" example:"30"`
+	FollowedAt    time.Time `json:"followed_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type FollowingResponse struct {
+	Authors    []FollowedAuthorResponse   `json:"authors"`
+	Categories []FollowedCategoryResponse `json:"categories"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+type NotificationResponse struct {
+	NotificationID uint       `json:"notification_id" example:"1"`
+	Type           string     `json:"type" example:"new_article"`
+	Message        string     `json:"message" example:"New article from Rahadina Budiman Sundara: Olahraga Pagi"`
+	ArticleID      uint       `json:"article_id" example:"1"`
+	ArticleSlug    string     `json:"article_slug" example:"olahraga-pagi"`
+	Thumbnail      string     `json:"thumbnail" example:"1685548428.jpg"`
+	Read           bool       `json:"read" example:"false"`
+	ReadAt         *time.Time `json:"read_at" example:"2023-05-17T15:07:16.504+07:00"`
+	CreatedAt      time.Time  `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
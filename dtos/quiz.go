@@ -0,0 +1,82 @@
+package dtos
+
+import "time"
+
+type QuizRequest struct {
+	Title        string                `json:"title" form:"title" example:"Kuis Kebugaran"`
+	PassingScore int                   `json:"passing_score" form:"passing_score" validate:"omitempty,min=1,max=100" example:"70"`
+	Questions    []QuizQuestionRequest `json:"questions" form:"questions" validate:"required,min=1,dive"`
+}
+
+// Every question needs exactly one correct option
+type QuizQuestionRequest struct {
+	Question    string              `json:"question" form:"question" validate:"required" example:"Berapa menit olahraga yang dianjurkan per hari?"`
+	Explanation string              `json:"explanation" form:"explanation" example:"WHO menganjurkan 30 menit aktivitas fisik setiap hari"`
+	Options     []QuizOptionRequest `json:"options" form:"options" validate:"required,min=2,dive"`
+}
+
+type QuizOptionRequest struct {
+	Text      string `json:"text" form:"text" validate:"required" example:"30 menit"`
+	IsCorrect bool   `json:"is_correct" form:"is_correct" example:"true"`
+}
+
+type QuizResponse struct {
+	QuizID       uint                   `json:"quiz_id" example:"1"`
+	ArticleID    uint                   `json:"article_id" example:"1"`
+	Title        string                 `json:"title" example:"Kuis Kebugaran"`
+	PassingScore int                    `json:"passing_score" example:"70"`
+	BestScore    *int                   `json:"best_score,omitempty" example:"80"`
+	Questions    []QuizQuestionResponse `json:"questions"`
+	UpdatedAt    time.Time              `json:"updated_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+// Explanation and correct options are only shown to admins and in attempt feedback
+type QuizQuestionResponse struct {
+	QuestionID  uint                 `json:"question_id" example:"1"`
+	Question    string               `json:"question" example:"Berapa menit olahraga yang dianjurkan per hari?"`
+	Explanation string               `json:"explanation,omitempty" example:"WHO menganjurkan 30 menit aktivitas fisik setiap hari"`
+	Options     []QuizOptionResponse `json:"options"`
+}
+
+type QuizOptionResponse struct {
+	OptionID  uint   `json:"option_id" example:"1"`
+	Text      string `json:"text" example:"30 menit"`
+	IsCorrect *bool  `json:"is_correct,omitempty" example:"true"`
+}
+
+type QuizAttemptRequest struct {
+	Answers []QuizAnswerRequest `json:"answers" form:"answers" validate:"required,min=1,dive"`
+}
+
+type QuizAnswerRequest struct {
+	QuestionID uint `json:"question_id" form:"question_id" validate:"required" example:"1"`
+	OptionID   uint `json:"option_id" form:"option_id" validate:"required" example:"2"`
+}
+
+type QuizAttemptResponse struct {
+	AttemptID      uint                   `json:"attempt_id" example:"1"`
+	ArticleID      uint                   `json:"article_id" example:"1"`
+	Score          int                    `json:"score" example:"80"`
+	CorrectAnswers int                    `json:"correct_answers" example:"4"`
+	TotalQuestions int                    `json:"total_questions" example:"5"`
+	Passed         bool                   `json:"passed" example:"true"`
+	BestScore      int                    `json:"best_score" example:"80"`
+	Feedback       []QuizFeedbackResponse `json:"feedback"`
+	CreatedAt      time.Time              `json:"created_at" example:"2023-05-17T15:07:16.504+07:00"`
+}
+
+type QuizFeedbackResponse struct {
+	QuestionID       uint   `json:"question_id" example:"1"`
+	SelectedOptionID uint   `json:"selected_option_id" example:"2"`
+	CorrectOptionID  uint   `json:"correct_option_id" example:"1"`
+	Correct          bool   `json:"correct" example:"false"`
+	Explanation      string `json:"explanation" example:"WHO menganjurkan 30 menit aktivitas fisik setiap hari"`
+}
+
+type UserQuizScoreResponse struct {
+	ArticleID    uint   `json:"article_id" example:"1"`
+	ArticleTitle string `json:"article_title" example:"judulArticle"`
+	BestScore    int    `json:"best_score" example:"80"`
+	Attempts     int    `json:"attempts" example:"2"`
+	Passed       bool   `json:"passed" example:"true"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+type ReadingProgressRequest struct {
+	Progress int `json:"progress" form:"progress" validate:"min=0,max=100" example:"45"`
+}
+
+type ReadingHistoryResponse struct {
+	ArticleID   uint                   `json:"article_id" example:"1"`
+	Progress    int                    `json:"progress" example:"45"`
+	Completed   bool                   `json:"completed" example:"false"`
+	CompletedAt *time.Time             `json:"completed_at" example:"2023-05-17T15:07:16.504+07:00"`
+	LastReadAt  time.Time              `json:"last_read_at" example:"2023-05-17T15:07:16.504+07:00"`
+	Article     *ArticleDetailResponse `json:"article,omitempty"`
+}
@@ -0,0 +1,19 @@
+package dtos
+
+import "time"
+
+type SchedulerStatusResponse struct {
+	Running        bool       `json:"running" example:"true"`
+	Interval       string     `json:"interval" example:"1m0s"`
+	LastRunAt      *time.Time `json:"last_run_at" example:"2023-05-17T15:07:16.504+07:00"`
+	NextRunAt      *time.Time `json:"next_run_at" example:"2023-05-17T15:08:16.504+07:00"`
+	LastPublished  int        `json:"last_published" example:"1"`
+	TotalPublished int        `json:"total_published" example:"10"`
+	LastError      string     `json:"last_error" example:""`
+}
+
+type HealthResponse struct {
+	Status    string                  `json:"status" example:"ok"`
+	Database  string                  `json:"database" example:"ok"`
+	Scheduler SchedulerStatusResponse `json:"scheduler"`
+}
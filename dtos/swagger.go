@@ -53,6 +53,18 @@ type LikedStatusOKResponse struct {
 	Data       ArticleLiked `json:"data" form:"data"`
 }
 
+type ArticleLikeStatusOKResponse struct {
+	StatusCode int                 `json:"status_code" example:"200"`
+	Message    string              `json:"message" example:"Article has been liked"`
+	Data       ArticleLikeResponse `json:"data"`
+}
+
+type ArticleBookmarkStatusOKResponse struct {
+	StatusCode int                     `json:"status_code" example:"200"`
+	Message    string                  `json:"message" example:"Article has been bookmarked"`
+	Data       ArticleBookmarkResponse `json:"data"`
+}
+
 type AdminStatusOKResponse struct {
 	StatusCode int                 `json:"status_code" example:"200"`
 	Message    string              `json:"message" example:"Successfully get user credentials"`
@@ -143,3 +155,184 @@ type InternalServerErrorResponse struct {
 	Message    string      `json:"message" example:"Internal Server Error"`
 	Errors     interface{} `json:"errors"`
 }
+
+type GetAllCategoryStatusOKResponse struct {
+	StatusCode int                `json:"status_code" example:"200"`
+	Message    string             `json:"message" example:"Successfully get all category"`
+	Data       []CategoryResponse `json:"data"`
+}
+
+type CategoryStatusOKResponse struct {
+	StatusCode int              `json:"status_code" example:"200"`
+	Message    string           `json:"message" example:"Successfully get category"`
+	Data       CategoryResponse `json:"data"`
+}
+
+type CategoryCreatedResponse struct {
+	StatusCode int              `json:"status_code" example:"201"`
+	Message    string           `json:"message" example:"Successfully create category"`
+	Data       CategoryResponse `json:"data"`
+}
+
+type TagCloudStatusOKResponse struct {
+	StatusCode int                `json:"status_code" example:"200"`
+	Message    string             `json:"message" example:"Successfully get tag cloud"`
+	Data       []TagCloudResponse `json:"data"`
+}
+
+type GetAllCommentStatusOKResponse struct {
+	StatusCode int               `json:"status_code" example:"200"`
+	Message    string            `json:"message" example:"Successfully get comments"`
+	Data       []CommentResponse `json:"data"`
+	Meta       helpers.Meta      `json:"meta"`
+}
+
+type CommentStatusOKResponse struct {
+	StatusCode int             `json:"status_code" example:"200"`
+	Message    string          `json:"message" example:"Successfully update comment"`
+	Data       CommentResponse `json:"data"`
+}
+
+type CommentCreatedResponse struct {
+	StatusCode int             `json:"status_code" example:"201"`
+	Message    string          `json:"message" example:"Comment is waiting for moderation"`
+	Data       CommentResponse `json:"data"`
+}
+
+type HealthStatusOKResponse struct {
+	StatusCode int            `json:"status_code" example:"200"`
+	Message    string         `json:"message" example:"Service is healthy"`
+	Data       HealthResponse `json:"data"`
+}
+
+type GetAllArticleRevisionStatusOKResponse struct {
+	StatusCode int                       `json:"status_code" example:"200"`
+	Message    string                    `json:"message" example:"Successfully get article revisions"`
+	Data       []ArticleRevisionResponse `json:"data"`
+}
+
+type ArticleRevisionDiffStatusOKResponse struct {
+	StatusCode int                         `json:"status_code" example:"200"`
+	Message    string                      `json:"message" example:"Successfully get article revision diff"`
+	Data       ArticleRevisionDiffResponse `json:"data"`
+}
+
+type ArticlePreviewStatusOKResponse struct {
+	StatusCode int                    `json:"status_code" example:"200"`
+	Message    string                 `json:"message" example:"Successfully preview article"`
+	Data       ArticlePreviewResponse `json:"data"`
+}
+
+type CollectionStatusOKResponse struct {
+	StatusCode int                `json:"status_code" example:"200"`
+	Message    string             `json:"message" example:"Successfully get collection"`
+	Data       CollectionResponse `json:"data"`
+}
+
+type GetAllCollectionStatusOKResponse struct {
+	StatusCode int                  `json:"status_code" example:"200"`
+	Message    string               `json:"message" example:"Successfully get collections"`
+	Data       []CollectionResponse `json:"data"`
+	Meta       helpers.Meta         `json:"meta"`
+}
+
+type ReadingProgressStatusOKResponse struct {
+	StatusCode int                    `json:"status_code" example:"200"`
+	Message    string                 `json:"message" example:"Reading progress has been saved"`
+	Data       ReadingHistoryResponse `json:"data"`
+}
+
+type GetAllReadingHistoryStatusOKResponse struct {
+	StatusCode int                      `json:"status_code" example:"200"`
+	Message    string                   `json:"message" example:"Successfully get reading history"`
+	Data       []ReadingHistoryResponse `json:"data"`
+	Meta       helpers.Meta             `json:"meta"`
+}
+
+type QuizStatusOKResponse struct {
+	StatusCode int          `json:"status_code" example:"200"`
+	Message    string       `json:"message" example:"Successfully get quiz"`
+	Data       QuizResponse `json:"data"`
+}
+
+type QuizAttemptStatusOKResponse struct {
+	StatusCode int                 `json:"status_code" example:"201"`
+	Message    string              `json:"message" example:"Quiz has been scored"`
+	Data       QuizAttemptResponse `json:"data"`
+}
+
+type CourseStatusOKResponse struct {
+	StatusCode int            `json:"status_code" example:"200"`
+	Message    string         `json:"message" example:"Successfully get course"`
+	Data       CourseResponse `json:"data"`
+}
+
+type GetAllCourseStatusOKResponse struct {
+	StatusCode int              `json:"status_code" example:"200"`
+	Message    string           `json:"message" example:"Successfully get courses"`
+	Data       []CourseResponse `json:"data"`
+	Meta       helpers.Meta     `json:"meta"`
+}
+
+type GetAllCertificateStatusOKResponse struct {
+	StatusCode int                   `json:"status_code" example:"200"`
+	Message    string                `json:"message" example:"Successfully get certificates"`
+	Data       []CertificateResponse `json:"data"`
+	Meta       helpers.Meta          `json:"meta"`
+}
+
+type CertificateVerifyStatusOKResponse struct {
+	StatusCode int                       `json:"status_code" example:"200"`
+	Message    string                    `json:"message" example:"Certificate is valid"`
+	Data       CertificateVerifyResponse `json:"data"`
+}
+
+type AchievementStatusOKResponse struct {
+	StatusCode int                 `json:"status_code" example:"200"`
+	Message    string              `json:"message" example:"Successfully get achievements"`
+	Data       AchievementResponse `json:"data"`
+}
+
+type LeaderboardStatusOKResponse struct {
+	StatusCode int                 `json:"status_code" example:"200"`
+	Message    string              `json:"message" example:"Successfully get leaderboard"`
+	Data       LeaderboardResponse `json:"data"`
+}
+
+type BadgeStatusOKResponse struct {
+	StatusCode int           `json:"status_code" example:"200"`
+	Message    string        `json:"message" example:"Successfully get badge"`
+	Data       BadgeResponse `json:"data"`
+}
+
+type GetAllBadgeStatusOKResponse struct {
+	StatusCode int             `json:"status_code" example:"200"`
+	Message    string          `json:"message" example:"Successfully get badges"`
+	Data       []BadgeResponse `json:"data"`
+}
+
+type FollowStatusOKResponse struct {
+	StatusCode int            `json:"status_code" example:"200"`
+	Message    string         `json:"message" example:"Successfully followed"`
+	Data       FollowResponse `json:"data"`
+}
+
+type FollowingStatusOKResponse struct {
+	StatusCode int               `json:"status_code" example:"200"`
+	Message    string            `json:"message" example:"Successfully get following"`
+	Data       FollowingResponse `json:"data"`
+}
+
+type AuthorStatusOKResponse struct {
+	StatusCode int            `json:"status_code" example:"200"`
+	Message    string         `json:"message" example:"Successfully get author"`
+	Data       AuthorResponse `json:"data"`
+	Meta       helpers.Meta   `json:"meta"`
+}
+
+type GetAllNotificationStatusOKResponse struct {
+	StatusCode int                    `json:"status_code" example:"200"`
+	Message    string                 `json:"message" example:"Successfully get notifications"`
+	Data       []NotificationResponse `json:"data"`
+	Meta       helpers.Meta           `json:"meta"`
+}
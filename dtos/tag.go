@@ -0,0 +1,15 @@
+package dtos
+
+type TagCloudResponse struct {
+	TagID        uint   `json:"tag_id" example:"1"`
+	Name         string `json:"name" example:"Diet Sehat"`
+	Slug         string `json:"slug" example:"diet-sehat"`
+	ArticleCount int    `json:"article_count" example:"10"`
+}
+
+// Short tag info embedded in article responses
+type ArticleTagResponse struct {
+	TagID uint   `json:"tag_id" example:"1"`
+	Name  string `json:"name" example:"Diet Sehat"`
+	Slug  string `json:"slug" example:"diet-sehat"`
+}
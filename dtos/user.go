@@ -54,11 +54,12 @@ type UpdateUserResponse struct {
 }
 
 type UserProfileResponse struct {
-	ID       uint   `json:"id" form:"id" example:"1"`
-	Username string `json:"username" form:"username" validate:"required" example:"r4ha"`
-	Nama     string `json:"nama" form:"nama" example:"Rahadina Budiman Sundara"`
-	Email    string `json:"email" form:"email" example:"me@r4ha.com"`
-	Role     string `json:"role" form:"role" example:"Admin"`
+	ID         uint                    `json:"id" form:"id" example:"1"`
+	Username   string                  `json:"username" form:"username" validate:"required" example:"r4ha"`
+	Nama       string                  `json:"nama" form:"nama" example:"Rahadina Budiman Sundara"`
+	Email      string                  `json:"email" form:"email" example:"me@r4ha.com"`
+	Role       string                  `json:"role" form:"role" example:"Admin"`
+	QuizScores []UserQuizScoreResponse `json:"quiz_scores"`
 }
 
 type ChangePasswordUserRequest struct {
@@ -12,16 +12,20 @@ require (
 require (
 	github.com/joho/godotenv v1.5.1
 	github.com/k3a/html2text v1.2.1
+	github.com/microcosm-cc/bluemonday v1.0.24
 	github.com/spf13/viper v1.15.0
 	github.com/thanhpk/randstr v1.0.6
+	github.com/yuin/goldmark v1.5.4
 	gopkg.in/gomail.v2 v2.0.0-20160411212932-81ebce5c23df
 	gorm.io/driver/mysql v1.5.1
 	gorm.io/gorm v1.25.1
 )
 
 require (
+	github.com/aymerick/douceur v0.2.0 // indirect
 	github.com/creasty/defaults v1.5.1 // indirect
 	github.com/google/uuid v1.2.0 // indirect
+	github.com/gorilla/css v1.0.0 // indirect
 	github.com/gorilla/schema v1.2.0 // indirect
 )
 
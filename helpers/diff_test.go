@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineDiff(t *testing.T) {
+	t.Run("Test Same Text", func(t *testing.T) {
+		lines := LineDiff("a\nb", "a\nb")
+		assert.Equal(t, []DiffLine{
+			{Op: DiffEqual, Text: "a"},
+			{Op: DiffEqual, Text: "b"},
+		}, lines)
+	})
+
+	t.Run("Test Changed Line", func(t *testing.T) {
+		lines := LineDiff("a\nb\nc", "a\nx\nc\nd")
+		assert.Equal(t, []DiffLine{
+			{Op: DiffEqual, Text: "a"},
+			{Op: DiffDelete, Text: "b"},
+			{Op: DiffInsert, Text: "x"},
+			{Op: DiffEqual, Text: "c"},
+			{Op: DiffInsert, Text: "d"},
+		}, lines)
+	})
+
+	t.Run("Test Empty Text", func(t *testing.T) {
+		lines := LineDiff("", "a")
+		assert.Equal(t, []DiffLine{{Op: DiffInsert, Text: "a"}}, lines)
+	})
+}
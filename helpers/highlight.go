@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Split search query into unique lowercase terms
+func SearchTerms(query string) []string {
+	var (
+		terms []string
+		seen  = map[string]bool{}
+	)
+
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		term = strings.Trim(term, `"'+-*~<>()`)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// Escape LIKE wildcards of a search term, the query must use ESCAPE '!'
+func EscapeLike(term string) string {
+	replacer := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+	return replacer.Replace(term)
+}
+
+// Build an escaped snippet around the first matched term and wrap every match with <mark>
+func HighlightSnippet(text string, terms []string, radius int) string {
+	if text == "" || len(terms) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, regexp.QuoteMeta(term))
+	}
+	re := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+
+	runes := []rune(text)
+	loc := re.FindStringIndex(text)
+	if loc == nil {
+		return ""
+	}
+
+	// Convert byte offset into rune offset before cutting the snippet
+	matchStart := len([]rune(text[:loc[0]]))
+	start := matchStart - radius
+	if start < 0 {
+		start = 0
+	}
+	end := matchStart + radius
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	// Escape the text between matches so the snippet is safe to render as HTML
+	snippet := string(runes[start:end])
+	var builder strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringIndex(snippet, -1) {
+		builder.WriteString(html.EscapeString(snippet[last:match[0]]))
+		builder.WriteString("<mark>" + html.EscapeString(snippet[match[0]:match[1]]) + "</mark>")
+		last = match[1]
+	}
+	builder.WriteString(html.EscapeString(snippet[last:]))
+	highlighted := builder.String()
+
+	if start > 0 {
+		highlighted = "..." + highlighted
+	}
+	if end < len(runes) {
+		highlighted = highlighted + "..."
+	}
+
+	return highlighted
+}
@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightSnippet(t *testing.T) {
+	t.Run("Test Search Terms", func(t *testing.T) {
+		terms := SearchTerms(`Kebugaran  "tubuh" kebugaran`)
+		assert.Equal(t, []string{"kebugaran", "tubuh"}, terms)
+	})
+
+	t.Run("Test Escape Like", func(t *testing.T) {
+		assert.Equal(t, "100!% diet!_sehat!!", EscapeLike("100% diet_sehat!"))
+	})
+
+	t.Run("Test Highlight Match", func(t *testing.T) {
+		snippet := HighlightSnippet("Menjaga Kebugaran tubuh", []string{"kebugaran"}, 80)
+		assert.Equal(t, "Menjaga <mark>Kebugaran</mark> tubuh", snippet)
+	})
+
+	t.Run("Test Highlight Escape HTML", func(t *testing.T) {
+		snippet := HighlightSnippet("<b>olahraga</b> & diet", []string{"diet"}, 80)
+		assert.Equal(t, "&lt;b&gt;olahraga&lt;/b&gt; &amp; <mark>diet</mark>", snippet)
+	})
+
+	t.Run("Test Highlight Trimmed", func(t *testing.T) {
+		snippet := HighlightSnippet("aaaaaaaaaa diet bbbbbbbbbb", []string{"diet"}, 5)
+		assert.Equal(t, "...aaaa <mark>diet</mark> ...", snippet)
+	})
+
+	t.Run("Test No Match", func(t *testing.T) {
+		assert.Empty(t, HighlightSnippet("olahraga", []string{"diet"}, 80))
+	})
+}
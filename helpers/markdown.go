@@ -0,0 +1,92 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// Heading of the rendered article used to build the table of contents
+type TOCEntry struct {
+	Level int    `json:"level" example:"2"`
+	Text  string `json:"text" example:"Manfaat Olahraga"`
+	ID    string `json:"id" example:"manfaat-olahraga"`
+}
+
+// Raw HTML is passed through by goldmark so older HTML descriptions keep working,
+// the sanitizer below is what keeps the output XSS safe.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+var markdownPolicy = newMarkdownPolicy()
+
+func newMarkdownPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("id").OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("code")
+	policy.RequireNoReferrerOnLinks(true)
+
+	return policy
+}
+
+// Render Markdown into sanitized HTML and collect the headings for the table of contents
+func RenderMarkdown(source string) (string, []TOCEntry) {
+	src := []byte(source)
+	doc := markdown.Parser().Parse(text.NewReader(src))
+
+	toc := []TOCEntry{}
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		entry := TOCEntry{Level: heading.Level, Text: string(heading.Text(src))}
+		if id, ok := heading.AttributeString("id"); ok {
+			if value, ok := id.([]byte); ok {
+				entry.ID = string(value)
+			}
+		}
+		toc = append(toc, entry)
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	// Rendering into a buffer does not fail
+	var buf bytes.Buffer
+	markdown.Renderer().Render(&buf, src, doc)
+
+	return markdownPolicy.Sanitize(buf.String()), toc
+}
+
+// Encode the table of contents as JSON to store it with the rendered article
+func EncodeTOC(toc []TOCEntry) string {
+	// Marshaling plain structs does not fail
+	data, _ := json.Marshal(toc)
+
+	return string(data)
+}
+
+// Decode a stored table of contents, an empty or invalid value has no headings
+func DecodeTOC(data string) []TOCEntry {
+	toc := []TOCEntry{}
+	if data == "" {
+		return toc
+	}
+
+	if err := json.Unmarshal([]byte(data), &toc); err != nil {
+		return []TOCEntry{}
+	}
+
+	return toc
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	t.Run("Test Render Heading And TOC", func(t *testing.T) {
+		html, toc := RenderMarkdown("# Olahraga Pagi\n\nLari *santai*.\n\n## Manfaat\n")
+		assert.Contains(t, html, `<h1 id="olahraga-pagi">Olahraga Pagi</h1>`)
+		assert.Contains(t, html, "<em>santai</em>")
+		assert.Equal(t, []TOCEntry{
+			{Level: 1, Text: "Olahraga Pagi", ID: "olahraga-pagi"},
+			{Level: 2, Text: "Manfaat", ID: "manfaat"},
+		}, toc)
+	})
+
+	t.Run("Test Sanitize Script", func(t *testing.T) {
+		html, _ := RenderMarkdown("Halo <script>alert(1)</script> <a href=\"javascript:alert(1)\" onclick=\"x()\">klik</a>")
+		assert.NotContains(t, html, "<script")
+		assert.NotContains(t, html, "javascript:")
+		assert.NotContains(t, html, "onclick")
+	})
+
+	t.Run("Test Empty Markdown", func(t *testing.T) {
+		html, toc := RenderMarkdown("")
+		assert.Empty(t, html)
+		assert.Empty(t, toc)
+	})
+}
+
+func TestTOCEncoding(t *testing.T) {
+	toc := []TOCEntry{{Level: 2, Text: "Manfaat", ID: "manfaat"}}
+
+	assert.Equal(t, toc, DecodeTOC(EncodeTOC(toc)))
+	assert.Equal(t, "[]", EncodeTOC([]TOCEntry{}))
+	assert.Equal(t, []TOCEntry{}, DecodeTOC(""))
+	assert.Equal(t, []TOCEntry{}, DecodeTOC("not json"))
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFDocument(t *testing.T) {
+	t.Run("Test Document Structure", func(t *testing.T) {
+		doc := NewPDFDocument(842, 595)
+		doc.CenteredText(300, 24, true, "Certificate")
+		pdf := doc.Bytes()
+
+		assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+		assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+		assert.Contains(t, string(pdf), "/MediaBox [0 0 842.00 595.00]")
+		assert.Contains(t, string(pdf), "(Certificate) Tj")
+	})
+
+	t.Run("Test Cross Reference Offsets", func(t *testing.T) {
+		pdf := NewPDFDocument(100, 100).Bytes()
+
+		startxref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(pdf)
+		offset, _ := strconv.Atoi(string(startxref[1]))
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte("xref\n")))
+
+		for i, entry := range regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(pdf, -1) {
+			offset, _ := strconv.Atoi(string(entry[1]))
+			assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(strconv.Itoa(i+1)+" 0 obj")))
+		}
+	})
+
+	t.Run("Test Text Escaped And Encoded", func(t *testing.T) {
+		doc := NewPDFDocument(100, 100)
+		doc.Text(10, 10, 12, false, `Kursus (Dasar) \ Lanjut`)
+		doc.Text(10, 30, 12, false, "Café 日本")
+		pdf := string(doc.Bytes())
+
+		assert.Contains(t, pdf, `(Kursus \(Dasar\) \\ Lanjut) Tj`)
+		assert.Contains(t, pdf, "(Caf\xe9 ??) Tj")
+	})
+}
+
+func TestPDFTextWidth(t *testing.T) {
+	assert.Equal(t, 0.0, PDFTextWidth("", 12, false))
+	assert.InDelta(t, 6.672, PDFTextWidth("a", 12, false), 0.001)
+	assert.InDelta(t, 7.332, PDFTextWidth("b", 12, true), 0.001)
+}
@@ -0,0 +1,26 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Average adult silent reading speed used for the reading time estimate
+const WordsPerMinute = 200
+
+var plainTextPolicy = bluemonday.StrictPolicy()
+
+// Plain text of a Markdown document without markup, link targets or images
+func MarkdownText(markdown string) string {
+	html, _ := RenderMarkdown(markdown)
+
+	return plainTextPolicy.Sanitize(html)
+}
+
+// Count words of a Markdown text and estimate its reading time in minutes
+func ReadingStats(markdown string) (int, int) {
+	words := len(strings.Fields(MarkdownText(markdown)))
+
+	return words, (words + WordsPerMinute - 1) / WordsPerMinute
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadingStats(t *testing.T) {
+	t.Run("Test Markdown Syntax Not Counted", func(t *testing.T) {
+		words, minutes := ReadingStats("## Olahraga Pagi\n\n* lari **santai**\n* [jalan kaki](https://bedu.keyzex.com)")
+		assert.Equal(t, 6, words)
+		assert.Equal(t, 1, minutes)
+	})
+
+	t.Run("Test Reading Time Rounded Up", func(t *testing.T) {
+		words, minutes := ReadingStats(strings.Repeat("kata ", 401))
+		assert.Equal(t, 401, words)
+		assert.Equal(t, 3, minutes)
+	})
+
+	t.Run("Test Empty Text", func(t *testing.T) {
+		words, minutes := ReadingStats("")
+		assert.Equal(t, 0, words)
+		assert.Equal(t, 0, minutes)
+	})
+}
@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"sort"
+	"time"
+)
+
+// Current and longest run of consecutive days with activity, days follow the given location.
+// The current streak stays alive until the end of the day after the last activity.
+func DailyStreak(activity []time.Time, loc *time.Location, now time.Time) (current int, longest int) {
+	seen := map[int64]bool{}
+	var days []int64
+	for _, at := range activity {
+		day := dayNumber(at.In(loc))
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	if len(days) == 0 {
+		return 0, 0
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && day == days[i-1]+1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	today := dayNumber(now.In(loc))
+	if last := days[len(days)-1]; last == today || last == today-1 {
+		current = run
+	}
+
+	return current, longest
+}
+
+// Days since the unix epoch of the calendar date, independent of the location offset
+func dayNumber(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyStreak(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	now := time.Date(2023, 6, 10, 20, 0, 0, 0, jakarta)
+
+	t.Run("Test Consecutive Days Counted Once", func(t *testing.T) {
+		current, longest := DailyStreak([]time.Time{
+			time.Date(2023, 6, 8, 9, 0, 0, 0, jakarta),
+			time.Date(2023, 6, 9, 9, 0, 0, 0, jakarta),
+			time.Date(2023, 6, 9, 21, 0, 0, 0, jakarta),
+			time.Date(2023, 6, 10, 7, 0, 0, 0, jakarta),
+		}, jakarta, now)
+		assert.Equal(t, 3, current)
+		assert.Equal(t, 3, longest)
+	})
+
+	t.Run("Test Streak Kept Until End Of Next Day", func(t *testing.T) {
+		current, _ := DailyStreak([]time.Time{time.Date(2023, 6, 9, 9, 0, 0, 0, jakarta)}, jakarta, now)
+		assert.Equal(t, 1, current)
+
+		current, longest := DailyStreak([]time.Time{time.Date(2023, 6, 8, 9, 0, 0, 0, jakarta)}, jakarta, now)
+		assert.Equal(t, 0, current)
+		assert.Equal(t, 1, longest)
+	})
+
+	t.Run("Test Days Follow User Timezone", func(t *testing.T) {
+		// 16:00 and 18:00 UTC on the same date are two different days in Jakarta
+		activity := []time.Time{
+			time.Date(2023, 6, 9, 16, 0, 0, 0, time.UTC),
+			time.Date(2023, 6, 9, 18, 0, 0, 0, time.UTC),
+		}
+
+		current, _ := DailyStreak(activity, jakarta, now)
+		assert.Equal(t, 2, current)
+
+		current, _ = DailyStreak(activity, time.UTC, now)
+		assert.Equal(t, 1, current)
+	})
+
+	t.Run("Test Longest Streak In The Past", func(t *testing.T) {
+		current, longest := DailyStreak([]time.Time{
+			time.Date(2023, 6, 1, 9, 0, 0, 0, jakarta),
+			time.Date(2023, 6, 2, 9, 0, 0, 0, jakarta),
+			time.Date(2023, 6, 3, 9, 0, 0, 0, jakarta),
+			time.Date(2023, 6, 10, 9, 0, 0, 0, jakarta),
+		}, jakarta, now)
+		assert.Equal(t, 1, current)
+		assert.Equal(t, 3, longest)
+	})
+
+	t.Run("Test No Activity", func(t *testing.T) {
+		current, longest := DailyStreak(nil, jakarta, now)
+		assert.Equal(t, 0, current)
+		assert.Equal(t, 0, longest)
+	})
+}
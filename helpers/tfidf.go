@@ -0,0 +1,92 @@
+package helpers
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Common Indonesian and English words that say nothing about the topic
+var stopWords = map[string]bool{
+	"ada": true, "adalah": true, "agar": true, "akan": true, "and": true, "are": true, "atau": true,
+	"bagi": true, "bahwa": true, "banyak": true, "bisa": true, "dalam": true, "dan": true, "dapat": true,
+	"dari": true, "dengan": true, "for": true, "from": true, "harus": true, "hal": true, "ini": true,
+	"itu": true, "juga": true, "kami": true, "kita": true, "lebih": true, "oleh": true, "pada": true,
+	"para": true, "saat": true, "sangat": true, "sebagai": true, "secara": true, "sehingga": true,
+	"serta": true, "sudah": true, "telah": true, "tersebut": true, "that": true, "the": true,
+	"this": true, "untuk": true, "was": true, "with": true, "yang": true, "you": true, "your": true,
+}
+
+// Split text into lowercase words, short words and stop words are dropped
+func Tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) < 3 || stopWords[word] {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
+
+// Add the words of text to counts, weight lets titles count more than body text
+func AddTermCounts(counts map[string]float64, text string, weight float64) {
+	for _, token := range Tokenize(text) {
+		counts[token] += weight
+	}
+}
+
+// Turn term counts of every document into unit length TF-IDF vectors
+func TFIDFVectors(documents map[uint]map[string]float64) map[uint]map[string]float64 {
+	documentFrequency := make(map[string]int)
+	for _, counts := range documents {
+		for term := range counts {
+			documentFrequency[term]++
+		}
+	}
+
+	total := float64(len(documents))
+	vectors := make(map[uint]map[string]float64, len(documents))
+	for id, counts := range documents {
+		var sum float64
+		for _, count := range counts {
+			sum += count
+		}
+
+		vector := make(map[string]float64, len(counts))
+		var norm float64
+		for term, count := range counts {
+			// Smoothed IDF keeps terms found in every document above zero
+			idf := math.Log((1+total)/(1+float64(documentFrequency[term]))) + 1
+			vector[term] = count / sum * idf
+			norm += vector[term] * vector[term]
+		}
+
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+		vectors[id] = vector
+	}
+
+	return vectors
+}
+
+// Cosine similarity of two unit length vectors
+func CosineSimilarity(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	var similarity float64
+	for term, weight := range a {
+		similarity += weight * b[term]
+	}
+
+	return similarity
+}
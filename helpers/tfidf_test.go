@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTFIDF(t *testing.T) {
+	t.Run("Test Tokenize", func(t *testing.T) {
+		tokens := Tokenize("Manfaat Olahraga dan Diet yang sehat, di pagi hari!")
+		assert.Equal(t, []string{"manfaat", "olahraga", "diet", "sehat", "pagi", "hari"}, tokens)
+	})
+
+	t.Run("Test Similar Documents Score Higher", func(t *testing.T) {
+		documents := map[uint]map[string]float64{1: {}, 2: {}, 3: {}}
+		AddTermCounts(documents[1], "olahraga lari pagi untuk jantung sehat", 1)
+		AddTermCounts(documents[2], "lari pagi membuat jantung sehat", 1)
+		AddTermCounts(documents[3], "resep masakan sayur bayam", 1)
+
+		vectors := TFIDFVectors(documents)
+		assert.InDelta(t, 1, CosineSimilarity(vectors[1], vectors[1]), 0.0001)
+		assert.Greater(t, CosineSimilarity(vectors[1], vectors[2]), CosineSimilarity(vectors[1], vectors[3]))
+		assert.Equal(t, 0.0, CosineSimilarity(vectors[1], vectors[3]))
+	})
+
+	t.Run("Test Empty Document", func(t *testing.T) {
+		vectors := TFIDFVectors(map[uint]map[string]float64{1: {}})
+		assert.Empty(t, vectors[1])
+	})
+}
@@ -0,0 +1,18 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Unguessable random token encoded as hex, length is the number of random bytes
+func GenerateRandomToken(length int) (string, error) {
+	token := make([]byte, length)
+
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
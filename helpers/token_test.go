@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRandomToken(t *testing.T) {
+	t.Run("Test Token Length", func(t *testing.T) {
+		token, err := GenerateRandomToken(16)
+		assert.NoError(t, err)
+		assert.Len(t, token, 32)
+	})
+
+	t.Run("Test Token Is Random", func(t *testing.T) {
+		first, _ := GenerateRandomToken(16)
+		second, _ := GenerateRandomToken(16)
+		assert.NotEqual(t, first, second)
+	})
+}
@@ -1,11 +1,20 @@
 package main
 
 import (
+	"context"
 	"go_bedu/config"
 	_ "go_bedu/docs" // docs is generated by Swag CLI, you have to import it.
 	"go_bedu/helpers"
 	m "go_bedu/middlewares"
+	"go_bedu/repositories"
 	"go_bedu/routes"
+	"go_bedu/usecase"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	mid "github.com/labstack/echo/v4/middleware"
@@ -37,6 +46,10 @@ import (
 func main() {
 	e := echo.New()
 
+	// X-Forwarded-For is only trusted from proxies on loopback and private networks,
+	// so clients cannot pick their own IP for view dedup
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+
 	m.Log(e)
 	e.Pre(mid.RemoveTrailingSlash())
 
@@ -50,11 +63,90 @@ func main() {
 		panic(err)
 	}
 
-	routes.NewRoute(e, db)
+	// Convert legacy article labels into categories
+	err = repositories.NewCategoryRepository(db).MigrateArticleLabels()
+	if err != nil {
+		panic(err)
+	}
+
+	// Fill reading stats of articles saved before they were computed
+	err = repositories.NewArticleRepository(db).MigrateArticleReadingStats()
+	if err != nil {
+		panic(err)
+	}
+
+	// Render descriptions of articles saved before the rendered HTML was stored
+	err = repositories.NewArticleRepository(db).MigrateArticleDescriptionHTML()
+	if err != nil {
+		panic(err)
+	}
+
+	// Related articles are computed in background, existing articles first when none were computed yet
+	articleRecommender := usecase.NewArticleRecommender(repositories.NewArticleRelationRepository(db))
+	articleRecommender.Start()
+
+	// Publish scheduled articles in background
+	interval, err := strconv.Atoi(os.Getenv("SCHEDULER_INTERVAL"))
+	if err != nil || interval < 1 {
+		interval = 60
+	}
+	publishScheduler := usecase.NewPublishScheduler(repositories.NewArticleRepository(db), time.Duration(interval)*time.Second)
+
+	// Article views are buffered and written in batches
+	flushInterval, err := strconv.Atoi(os.Getenv("VIEW_FLUSH_INTERVAL"))
+	if err != nil || flushInterval < 1 {
+		flushInterval = 10
+	}
+	dedupWindow, err := strconv.Atoi(os.Getenv("VIEW_DEDUP_WINDOW"))
+	if err != nil || dedupWindow < 1 {
+		dedupWindow = 30
+	}
+	viewCounter := usecase.NewViewCounter(repositories.NewArticleRepository(db), time.Duration(flushInterval)*time.Second, time.Duration(dedupWindow)*time.Minute)
+	viewCounter.Start()
+
+	// Trending and popular rankings are rebuilt in background
+	rankingInterval, err := strconv.Atoi(os.Getenv("RANKING_INTERVAL"))
+	if err != nil || rankingInterval < 1 {
+		rankingInterval = 600
+	}
+	trendingDays, err := strconv.Atoi(os.Getenv("TRENDING_DAYS"))
+	if err != nil || trendingDays < 1 {
+		trendingDays = 7
+	}
+	rankingJob := usecase.NewRankingJob(repositories.NewArticleRankingRepository(db), time.Duration(rankingInterval)*time.Second, trendingDays)
+	rankingJob.Start()
+
+	routes.NewRoute(e, db, publishScheduler, viewCounter, articleRecommender)
+
+	// Started after the routes registered its published handlers, so the first run notifies followers too
+	publishScheduler.Start()
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 	var port = helpers.EnvPortOr("3000")
 
 	// Start server with TLS
-	e.Logger.Fatal(e.Start(port))
+	go func() {
+		err := e.Start(port)
+		if err != nil && err != http.ErrServerClosed {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	// Stop taking requests on SIGINT or SIGTERM before the buffered views are written
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = e.Shutdown(ctx)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+
+	viewCounter.Stop()
+	publishScheduler.Stop()
+	rankingJob.Stop()
+	articleRecommender.Stop()
 }
@@ -81,6 +81,51 @@ func VerifyToken(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// Optional variant of VerifyToken for public routes, guests and invalid tokens pass through without user information
+func OptionalToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader := c.Request().Header.Get("Authorization")
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		if tokenString == "" {
+			return next(c)
+		}
+
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return []byte(os.Getenv("SECRET_JWT")), nil
+		})
+		if err != nil || !token.Valid {
+			return next(c)
+		}
+
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return next(c)
+		}
+		userID, ok := claims["id"].(float64)
+		if !ok {
+			return next(c)
+		}
+		email, _ := claims["email"].(string)
+		role, _ := claims["role"].(string)
+
+		c.Set("userID", int(userID))
+		c.Set("email", email)
+		c.Set("role", role)
+
+		return next(c)
+	}
+}
+
+// ID of the user set by VerifyToken or OptionalToken, false for guests and admins
+func OptionalUser(c echo.Context) (uint, bool) {
+	userID, ok := c.Get("userID").(int)
+	if !ok || c.Get("role") != "User" {
+		return 0, false
+	}
+
+	return uint(userID), true
+}
+
 // Verifikasi Super Admin Middleware
 func VerifySuperAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Timezone of users who did not choose one, used for streaks and the weekly leaderboard
+const DefaultTimezone = "Asia/Jakarta"
+
+// Sources of points, a user gets the points of a source once
+const (
+	PointSourceVerifyEmail = "verify_email"
+	PointSourceReadArticle = "read_article"
+	PointSourceLikeArticle = "like_article"
+	PointSourcePassQuiz    = "pass_quiz"
+)
+
+// Points awarded per source
+var PointValues = map[string]int{
+	PointSourceVerifyEmail: 50,
+	PointSourceReadArticle: 10,
+	PointSourceLikeArticle: 2,
+	PointSourcePassQuiz:    25,
+}
+
+// Statistics a badge rule can require
+const (
+	BadgeMetricPoints        = "points"
+	BadgeMetricArticlesRead  = "articles_read"
+	BadgeMetricArticlesLiked = "articles_liked"
+	BadgeMetricQuizzesPassed = "quizzes_passed"
+	BadgeMetricReadingStreak = "reading_streak"
+)
+
+// Entry of the points ledger, source id is the article for article events and the user for email verification
+type PointTransaction struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+	UserID    uint      `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_point_source"`
+	User      User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Source    string    `json:"source" gorm:"size:32;uniqueIndex:idx_point_source"`
+	SourceID  uint      `json:"source_id" gorm:"uniqueIndex:idx_point_source"`
+	Points    int       `json:"points"`
+}
+
+// Badge rule, awarded when the metric of a user reaches the threshold
+type Badge struct {
+	gorm.Model
+	Code        string `json:"code" form:"code" gorm:"size:50;uniqueIndex"`
+	Name        string `json:"name" form:"name"`
+	Description string `json:"description" form:"description"`
+	Icon        string `json:"icon" form:"icon"`
+	Metric      string `json:"metric" form:"metric" gorm:"size:32"`
+	Threshold   int    `json:"threshold" form:"threshold"`
+}
+
+// Badge earned by a user, kept when the rule changes later
+type UserBadge struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_user_badge"`
+	User      User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	BadgeID   uint      `json:"badge_id" form:"badge_id" gorm:"uniqueIndex:idx_user_badge"`
+	Badge     Badge     `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AwardedAt time.Time `json:"awarded_at"`
+}
+
+// Badges created with the table, admins can change them afterwards
+var DefaultBadges = []Badge{
+	{Code: "first-read", Name: "First Read", Description: "Finish reading your first article", Metric: BadgeMetricArticlesRead, Threshold: 1},
+	{Code: "bookworm", Name: "Bookworm", Description: "Finish reading 25 articles", Metric: BadgeMetricArticlesRead, Threshold: 25},
+	{Code: "quiz-master", Name: "Quiz Master", Description: "Pass 10 article quizzes", Metric: BadgeMetricQuizzesPassed, Threshold: 10},
+	{Code: "supporter", Name: "Supporter", Description: "Like 10 articles", Metric: BadgeMetricArticlesLiked, Threshold: 10},
+	{Code: "streak-7", Name: "Full Week", Description: "Read articles 7 days in a row", Metric: BadgeMetricReadingStreak, Threshold: 7},
+	{Code: "points-500", Name: "Bedu Star", Description: "Collect 500 points", Metric: BadgeMetricPoints, Threshold: 500},
+}
@@ -15,5 +15,10 @@ type Administrator struct {
 	OTPReq           bool      `gorm:"not null"`
 	Verified         bool      `gorm:"not null"`
 	Token            string    `json:"-" gorm:"-"`
+	Bio              string    `json:"bio" form:"bio" gorm:"type:text"`
+	Website          string    `json:"website" form:"website"`
+	Twitter          string    `json:"twitter" form:"twitter"`
+	Instagram        string    `json:"instagram" form:"instagram"`
+	LinkedIn         string    `json:"linkedin" form:"linkedin"`
 	Articles         []Article `json:"articles" form:"articles" gorm:"foreignKey:AdministratorID"`
 }
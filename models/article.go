@@ -1,15 +1,41 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ArticleDraft     = "draft"
+	ArticleInReview  = "in_review"
+	ArticlePublished = "published"
+	ArticleArchived  = "archived"
+)
 
 type Article struct {
 	gorm.Model
-	AdministratorID uint   `json:"administrator_id" form:"administrator_id"`
-	Thumbnail       string `json:"thumbnail" form:"thumbnail"`
-	Title           string `json:"title" form:"title"`
-	Abstract        string `json:"abstract" form:"abstract"`
-	Description     string `json:"description" form:"description"`
-	Image           string `json:"image" form:"image"`
-	Label           string `json:"label" form:"label"`
-	Slug            string `json:"slug" form:"slug"`
+	AdministratorID uint       `json:"administrator_id" form:"administrator_id"`
+	CategoryID      *uint      `json:"category_id" form:"category_id"`
+	Category        *Category  `json:"category,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Thumbnail       string     `json:"thumbnail" form:"thumbnail"`
+	Title           string     `json:"title" form:"title"`
+	Abstract        string     `json:"abstract" form:"abstract"`
+	Description     string     `json:"description" form:"description"`
+	DescriptionHTML string     `json:"description_html" form:"description_html"`
+	TableOfContents string     `json:"table_of_contents" form:"table_of_contents" gorm:"type:text"`
+	WordCount       int        `json:"word_count" form:"word_count"`
+	ReadingTime     int        `json:"reading_time" form:"reading_time"`
+	Image           string     `json:"image" form:"image"`
+	Label           string     `json:"label" form:"label"`
+	Slug            string     `json:"slug" form:"slug" gorm:"size:191;uniqueIndex"`
+	MetaTitle       string     `json:"meta_title" form:"meta_title"`
+	MetaDescription string     `json:"meta_description" form:"meta_description"`
+	CanonicalURL    string     `json:"canonical_url" form:"canonical_url"`
+	OGImage         string     `json:"og_image" form:"og_image"`
+	Status          string     `json:"status" form:"status" gorm:"type:enum('draft', 'in_review', 'published', 'archived');default:'draft'; not-null;index"`
+	PublishedAt     *time.Time `json:"published_at" form:"published_at" gorm:"index"`
+	ScheduledAt     *time.Time `json:"scheduled_at" form:"scheduled_at" gorm:"index"`
+	Views           int        `json:"views" form:"views" gorm:"default:0"`
+	Tags            []Tag      `json:"tags,omitempty" gorm:"many2many:article_tags;"`
 }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Private reading list entry of a user, unlike likes bookmarks are never shown to others
+type ArticleBookmark struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UserID    uint      `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_article_bookmark"`
+	User      User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ArticleID uint      `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_article_bookmark"`
+	Article   Article   `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -2,9 +2,10 @@ package models
 
 import "gorm.io/gorm"
 
+// A user likes an article at most once, unlike removes the row so it can be liked again
 type ArticleLiked struct {
 	gorm.Model
-	ArticleID uint    `json:"article_id" form:"article_id"`
+	ArticleID uint    `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_article_liked"`
 	Article   Article `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	UserID    uint    `json:"user_id" form:"user_id"`
+	UserID    uint    `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_article_liked"`
 }
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	RankingTrending     = "trending"
+	RankingPopularWeek  = "popular_week"
+	RankingPopularMonth = "popular_month"
+	RankingPopularAll   = "popular_all"
+)
+
+// Cached ranking row, rebuilt periodically by the ranking job
+type ArticleRanking struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	Kind       string    `json:"kind" form:"kind" gorm:"size:32;index:idx_ranking_kind_position,priority:1"`
+	Position   int       `json:"position" form:"position" gorm:"index:idx_ranking_kind_position,priority:2"`
+	ArticleID  uint      `json:"article_id" form:"article_id"`
+	Article    Article   `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Score      float64   `json:"score" form:"score"`
+	Likes      int       `json:"likes" form:"likes"`
+	Views      int       `json:"views" form:"views"`
+	ComputedAt time.Time `json:"computed_at" form:"computed_at"`
+}
+
+// Views of an article on a single day, used for time based rankings
+type ArticleViewStat struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ArticleID uint      `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_article_view_day"`
+	Article   Article   `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Day       time.Time `json:"day" form:"day" gorm:"type:date;uniqueIndex:idx_article_view_day"`
+	Views     int       `json:"views" form:"views"`
+}
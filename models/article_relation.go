@@ -0,0 +1,11 @@
+package models
+
+// Precomputed similarity between two articles used for related article recommendations
+type ArticleRelation struct {
+	ID        uint    `json:"id" gorm:"primarykey"`
+	ArticleID uint    `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_article_relation"`
+	Article   Article `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	RelatedID uint    `json:"related_id" form:"related_id" gorm:"uniqueIndex:idx_article_relation"`
+	Related   Article `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Score     float64 `json:"score" form:"score"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "gorm.io/gorm"
+
+// Snapshot of the article content saved on every create and update
+type ArticleRevision struct {
+	gorm.Model
+	ArticleID       uint          `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_article_revision"`
+	Revision        int           `json:"revision" form:"revision" gorm:"uniqueIndex:idx_article_revision"`
+	AdministratorID uint          `json:"administrator_id" form:"administrator_id"`
+	Administrator   Administrator `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CategoryID      *uint         `json:"category_id" form:"category_id"`
+	Thumbnail       string        `json:"thumbnail" form:"thumbnail"`
+	Title           string        `json:"title" form:"title"`
+	Abstract        string        `json:"abstract" form:"abstract"`
+	Description     string        `json:"description" form:"description"`
+	Image           string        `json:"image" form:"image"`
+	Label           string        `json:"label" form:"label"`
+	Slug            string        `json:"slug" form:"slug"`
+	MetaTitle       string        `json:"meta_title" form:"meta_title"`
+	MetaDescription string        `json:"meta_description" form:"meta_description"`
+	CanonicalURL    string        `json:"canonical_url" form:"canonical_url"`
+	OGImage         string        `json:"og_image" form:"og_image"`
+	Tags            string        `json:"tags" form:"tags"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "gorm.io/gorm"
+
+// Previous slug of an article, kept so old links can redirect to the current slug
+type ArticleSlug struct {
+	gorm.Model
+	ArticleID uint    `json:"article_id" form:"article_id" gorm:"index"`
+	Article   Article `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Slug      string  `json:"slug" form:"slug" gorm:"size:191;uniqueIndex"`
+}
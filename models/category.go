@@ -0,0 +1,11 @@
+package models
+
+import "gorm.io/gorm"
+
+type Category struct {
+	gorm.Model
+	Name        string `json:"name" form:"name"`
+	Slug        string `json:"slug" form:"slug" gorm:"size:191;uniqueIndex"`
+	Description string `json:"description" form:"description"`
+	Icon        string `json:"icon" form:"icon"`
+}
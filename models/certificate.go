@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Certificate issued once per user and course, name and title are kept as printed on the PDF
+type Certificate struct {
+	ID               uint      `json:"id" gorm:"primarykey"`
+	CreatedAt        time.Time `json:"created_at"`
+	UserID           uint      `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_certificate"`
+	User             User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CourseID         uint      `json:"course_id" form:"course_id" gorm:"uniqueIndex:idx_certificate"`
+	Course           Course    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	VerificationCode string    `json:"verification_code" gorm:"size:32;uniqueIndex"`
+	FullName         string    `json:"fullname"`
+	CourseTitle      string    `json:"course_title"`
+	File             string    `json:"file"`
+	IssuedAt         time.Time `json:"issued_at"`
+}
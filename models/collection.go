@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Named reading list of a user, shared publicly once it has a share token
+type Collection struct {
+	gorm.Model
+	UserID      uint    `json:"user_id" form:"user_id" gorm:"index"`
+	User        User    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name        string  `json:"name" form:"name" gorm:"size:100;not null"`
+	Description string  `json:"description" form:"description" gorm:"type:text"`
+	ShareToken  *string `json:"-" gorm:"size:64;uniqueIndex"`
+}
+
+// Article inside a collection, items are listed by position
+type CollectionItem struct {
+	ID           uint       `json:"id" gorm:"primarykey"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CollectionID uint       `json:"collection_id" gorm:"uniqueIndex:idx_collection_item"`
+	Collection   Collection `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ArticleID    uint       `json:"article_id" gorm:"uniqueIndex:idx_collection_item"`
+	Article      Article    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Position     int        `json:"position"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "gorm.io/gorm"
+
+const (
+	CommentPending  = "Pending"
+	CommentApproved = "Approved"
+	CommentHidden   = "Hidden"
+)
+
+type Comment struct {
+	gorm.Model
+	ArticleID uint    `json:"article_id" form:"article_id" gorm:"index"`
+	Article   Article `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID    uint    `json:"user_id" form:"user_id"`
+	User      User    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ParentID  *uint   `json:"parent_id" form:"parent_id" gorm:"index"`
+	Body      string  `json:"body" form:"body" gorm:"type:text"`
+	Status    string  `json:"status" form:"status" gorm:"type:enum('Pending', 'Approved', 'Hidden');default:'Pending'; not-null"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Learning path made of ordered article lessons
+type Course struct {
+	gorm.Model
+	AdministratorID uint           `json:"administrator_id" form:"administrator_id"`
+	Title           string         `json:"title" form:"title"`
+	Slug            string         `json:"slug" form:"slug" gorm:"size:191;uniqueIndex"`
+	Description     string         `json:"description" form:"description" gorm:"type:text"`
+	Thumbnail       string         `json:"thumbnail" form:"thumbnail"`
+	Published       bool           `json:"published" form:"published" gorm:"default:false"`
+	Lessons         []CourseLesson `json:"lessons" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// Article of a course, a lesson requiring the quiz is only completed by passing it
+type CourseLesson struct {
+	ID          uint    `json:"id" gorm:"primarykey"`
+	CourseID    uint    `json:"course_id" gorm:"uniqueIndex:idx_course_lesson"`
+	ArticleID   uint    `json:"article_id" gorm:"uniqueIndex:idx_course_lesson;index"`
+	Article     Article `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Position    int     `json:"position"`
+	RequireQuiz bool    `json:"require_quiz"`
+}
+
+type CourseEnrollment struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UserID      uint       `json:"user_id" gorm:"uniqueIndex:idx_course_enrollment"`
+	User        User       `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CourseID    uint       `json:"course_id" gorm:"uniqueIndex:idx_course_enrollment"`
+	Course      Course     `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// Completed lesson of an enrolled user, keyed by article so editing the lessons keeps progress
+type CourseLessonCompletion struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	UserID      uint      `json:"user_id" gorm:"uniqueIndex:idx_course_lesson_completion"`
+	User        User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CourseID    uint      `json:"course_id" gorm:"uniqueIndex:idx_course_lesson_completion"`
+	Course      Course    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ArticleID   uint      `json:"article_id" gorm:"uniqueIndex:idx_course_lesson_completion"`
+	CompletedAt time.Time `json:"completed_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// User following an administrator as author
+type AuthorFollow struct {
+	ID              uint          `json:"id" gorm:"primarykey"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UserID          uint          `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_author_follow"`
+	User            User          `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AdministratorID uint          `json:"administrator_id" form:"administrator_id" gorm:"uniqueIndex:idx_author_follow;index"`
+	Administrator   Administrator `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type CategoryFollow struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	CreatedAt  time.Time `json:"created_at"`
+	UserID     uint      `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_category_follow"`
+	User       User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CategoryID uint      `json:"category_id" form:"category_id" gorm:"uniqueIndex:idx_category_follow;index"`
+	Category   Category  `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
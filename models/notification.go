@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Types of notifications
+const (
+	NotificationNewArticle = "new_article"
+)
+
+// Notification of a user, an article is notified once per type even when it is published again
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index"`
+	UserID    uint       `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_notification"`
+	User      User       `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type      string     `json:"type" gorm:"size:32;uniqueIndex:idx_notification"`
+	ArticleID uint       `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_notification"`
+	Article   Article    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Message   string     `json:"message"`
+	ReadAt    *time.Time `json:"read_at"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Default percentage of correct answers needed to pass a quiz
+const QuizDefaultPassingScore = 70
+
+// Multiple choice quiz attached to an article, replacing the quiz replaces its questions
+type Quiz struct {
+	ID           uint           `json:"id" gorm:"primarykey"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	ArticleID    uint           `json:"article_id" form:"article_id" gorm:"uniqueIndex"`
+	Article      Article        `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Title        string         `json:"title" form:"title"`
+	PassingScore int            `json:"passing_score" form:"passing_score"`
+	Questions    []QuizQuestion `json:"questions" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type QuizQuestion struct {
+	ID          uint         `json:"id" gorm:"primarykey"`
+	QuizID      uint         `json:"quiz_id" gorm:"index"`
+	Position    int          `json:"position"`
+	Question    string       `json:"question" gorm:"type:text"`
+	Explanation string       `json:"explanation" gorm:"type:text"`
+	Options     []QuizOption `json:"options" gorm:"foreignKey:QuestionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type QuizOption struct {
+	ID         uint   `json:"id" gorm:"primarykey"`
+	QuestionID uint   `json:"question_id" gorm:"index"`
+	Position   int    `json:"position"`
+	Text       string `json:"text" gorm:"type:text"`
+	IsCorrect  bool   `json:"is_correct"`
+}
+
+// Scored submission of a user, answers keep plain IDs so attempts survive quiz edits
+type QuizAttempt struct {
+	ID             uint         `json:"id" gorm:"primarykey"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UserID         uint         `json:"user_id" gorm:"index:idx_quiz_attempt_user"`
+	User           User         `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ArticleID      uint         `json:"article_id" gorm:"index:idx_quiz_attempt_user"`
+	Article        Article      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Score          int          `json:"score"`
+	CorrectAnswers int          `json:"correct_answers"`
+	TotalQuestions int          `json:"total_questions"`
+	Passed         bool         `json:"passed"`
+	Answers        []QuizAnswer `json:"answers" gorm:"foreignKey:AttemptID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type QuizAnswer struct {
+	ID         uint `json:"id" gorm:"primarykey"`
+	AttemptID  uint `json:"attempt_id" gorm:"index"`
+	QuestionID uint `json:"question_id"`
+	OptionID   uint `json:"option_id"`
+	Correct    bool `json:"correct"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Progress from which an article counts as read
+const ReadingCompleteProgress = 90
+
+// Last known reading position of a user in an article
+type ReadingHistory struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	UserID      uint       `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_reading_history"`
+	User        User       `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ArticleID   uint       `json:"article_id" form:"article_id" gorm:"uniqueIndex:idx_reading_history"`
+	Article     Article    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Progress    int        `json:"progress" form:"progress"`
+	LastReadAt  time.Time  `json:"last_read_at" gorm:"index"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// Days a user read anything, kept apart from ReadingHistory so re-reads and cleared history still count for the streak.
+// Days are UTC dates, the first and last read of a UTC day cover every local date it touches.
+type ReadingActivity struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	UserID      uint      `json:"user_id" form:"user_id" gorm:"uniqueIndex:idx_reading_activity"`
+	User        User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Day         string    `json:"day" gorm:"size:10;uniqueIndex:idx_reading_activity"`
+	FirstReadAt time.Time `json:"first_read_at"`
+	LastReadAt  time.Time `json:"last_read_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "gorm.io/gorm"
+
+type Tag struct {
+	gorm.Model
+	Name     string    `json:"name" form:"name"`
+	Slug     string    `json:"slug" form:"slug" gorm:"size:191;uniqueIndex"`
+	Articles []Article `json:"articles,omitempty" gorm:"many2many:article_tags;"`
+}
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"go_bedu/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Points and number of ledger entries of a user per source
+type PointSummary struct {
+	Source string
+	Count  int
+	Points int
+}
+
+// Points of a user collected in a period
+type LeaderboardEntry struct {
+	UserID       uint
+	Username     string
+	FullName     string
+	PhotoProfile string
+	Points       int
+}
+
+type AchievementRepository interface {
+	CreatePointTransaction(transaction models.PointTransaction) (bool, error)
+	GetPointSummaries(userId uint) ([]PointSummary, error)
+	GetPointsBetween(userId uint, since, until time.Time) (int, error)
+	GetPointTransactions(userId uint, limit int) ([]models.PointTransaction, error)
+	GetReadingTimes(userId uint) ([]time.Time, error)
+	GetBadges() ([]models.Badge, error)
+	GetBadgeByID(id uint) (models.Badge, error)
+	IsBadgeCodeTaken(code string, excludeID uint) (bool, error)
+	CreateBadge(badge models.Badge) (models.Badge, error)
+	UpdateBadge(badge models.Badge) (models.Badge, error)
+	DeleteBadge(badge models.Badge) error
+	GetUserBadges(userId uint) ([]models.UserBadge, error)
+	CreateUserBadges(userBadges []models.UserBadge) error
+	GetLeaderboard(since, until time.Time, limit int) ([]LeaderboardEntry, error)
+	GetLeaderboardRank(userId uint, since, until time.Time) (int, error)
+	UpdateAchievementSettings(userId uint, timezone string, optOut bool) error
+}
+
+type achievementRepository struct {
+	db *gorm.DB
+}
+
+func NewAchievementRepository(db *gorm.DB) AchievementRepository {
+	return &achievementRepository{db}
+}
+
+// Add points once per source, false when the user already got the points
+func (r *achievementRepository) CreatePointTransaction(transaction models.PointTransaction) (bool, error) {
+	result := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&transaction)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *achievementRepository) GetPointSummaries(userId uint) ([]PointSummary, error) {
+	var summaries []PointSummary
+
+	err := r.db.Model(&models.PointTransaction{}).
+		Select("source, COUNT(*) AS count, SUM(points) AS points").
+		Where("user_id = ?", userId).
+		Group("source").
+		Scan(&summaries).Error
+
+	return summaries, err
+}
+
+func (r *achievementRepository) GetPointsBetween(userId uint, since, until time.Time) (int, error) {
+	var points int
+
+	err := r.db.Model(&models.PointTransaction{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("user_id = ? AND created_at >= ? AND created_at < ?", userId, since, until).
+		Scan(&points).Error
+
+	return points, err
+}
+
+// Get latest ledger entries of a user
+func (r *achievementRepository) GetPointTransactions(userId uint, limit int) ([]models.PointTransaction, error) {
+	var transactions []models.PointTransaction
+
+	err := r.db.Where("user_id = ?", userId).Order("created_at DESC, id DESC").Limit(limit).Find(&transactions).Error
+
+	return transactions, err
+}
+
+// Get first and last read times of every reading day of the user, used for the streak
+func (r *achievementRepository) GetReadingTimes(userId uint) ([]time.Time, error) {
+	var activities []models.ReadingActivity
+
+	err := r.db.Select("first_read_at", "last_read_at").Where("user_id = ?", userId).Find(&activities).Error
+
+	times := make([]time.Time, 0, len(activities)*2)
+	for _, activity := range activities {
+		times = append(times, activity.FirstReadAt, activity.LastReadAt)
+	}
+
+	return times, err
+}
+
+func (r *achievementRepository) GetBadges() ([]models.Badge, error) {
+	var badges []models.Badge
+
+	err := r.db.Order("metric ASC, threshold ASC").Find(&badges).Error
+
+	return badges, err
+}
+
+func (r *achievementRepository) GetBadgeByID(id uint) (models.Badge, error) {
+	var badge models.Badge
+
+	err := r.db.Where("id = ?", id).First(&badge).Error
+
+	return badge, err
+}
+
+// Deleted badges keep their code because of the unique index
+func (r *achievementRepository) IsBadgeCodeTaken(code string, excludeID uint) (bool, error) {
+	var count int64
+
+	err := r.db.Unscoped().Model(&models.Badge{}).Where("code = ? AND id <> ?", code, excludeID).Count(&count).Error
+
+	return count > 0, err
+}
+
+func (r *achievementRepository) CreateBadge(badge models.Badge) (models.Badge, error) {
+	err := r.db.Create(&badge).Error
+
+	return badge, err
+}
+
+func (r *achievementRepository) UpdateBadge(badge models.Badge) (models.Badge, error) {
+	err := r.db.Save(&badge).Error
+
+	return badge, err
+}
+
+func (r *achievementRepository) DeleteBadge(badge models.Badge) error {
+	return r.db.Delete(&badge).Error
+}
+
+// Get badges earned by a user, badges deleted by an admin are left out
+func (r *achievementRepository) GetUserBadges(userId uint) ([]models.UserBadge, error) {
+	var userBadges []models.UserBadge
+
+	err := r.db.
+		Joins("JOIN badges ON badges.id = user_badges.badge_id AND badges.deleted_at IS NULL").
+		Where("user_badges.user_id = ?", userId).
+		Find(&userBadges).Error
+
+	return userBadges, err
+}
+
+// Award badges, badges earned before keep their first award time
+func (r *achievementRepository) CreateUserBadges(userBadges []models.UserBadge) error {
+	if len(userBadges) == 0 {
+		return nil
+	}
+
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&userBadges).Error
+}
+
+// Get users with the most points in a period, users who opted out are left out
+func (r *achievementRepository) GetLeaderboard(since, until time.Time, limit int) ([]LeaderboardEntry, error) {
+	var entries []LeaderboardEntry
+
+	err := r.leaderboardQuery(since, until).
+		Select("users.id AS user_id, users.username, users.full_name, users.photo_profile, SUM(point_transactions.points) AS points").
+		Group("users.id, users.username, users.full_name, users.photo_profile").
+		Order("points DESC, users.id ASC").
+		Limit(limit).
+		Scan(&entries).Error
+
+	return entries, err
+}
+
+// Rank of a user in the period, 0 when the user has no points or opted out
+func (r *achievementRepository) GetLeaderboardRank(userId uint, since, until time.Time) (int, error) {
+	var points int
+
+	err := r.leaderboardQuery(since, until).
+		Select("COALESCE(SUM(point_transactions.points), 0)").
+		Where("users.id = ?", userId).
+		Scan(&points).Error
+	if err != nil || points == 0 {
+		return 0, err
+	}
+
+	// Users ahead have more points, or the same points and a lower id like the leaderboard order
+	var ahead int64
+	err = r.db.Table("(?) AS totals", r.leaderboardQuery(since, until).
+		Select("users.id AS user_id, SUM(point_transactions.points) AS points").
+		Group("users.id")).
+		Where("totals.points > ? OR (totals.points = ? AND totals.user_id < ?)", points, points, userId).
+		Count(&ahead).Error
+
+	return int(ahead) + 1, err
+}
+
+func (r *achievementRepository) leaderboardQuery(since, until time.Time) *gorm.DB {
+	return r.db.Model(&models.PointTransaction{}).
+		Joins("JOIN users ON users.id = point_transactions.user_id AND users.deleted_at IS NULL AND users.leaderboard_opt_out = ?", false).
+		Where("point_transactions.created_at >= ? AND point_transactions.created_at < ?", since, until)
+}
+
+func (r *achievementRepository) UpdateAchievementSettings(userId uint, timezone string, optOut bool) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ?", userId).
+		UpdateColumns(map[string]interface{}{"timezone": timezone, "leaderboard_opt_out": optOut}).Error
+}
@@ -1,18 +1,51 @@
 package repositories
 
 import (
+	"go_bedu/helpers"
 	"go_bedu/models"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Optional filters applied on article listing and search
+type ArticleFilter struct {
+	CategoryID   uint
+	TagID        uint
+	Status       string
+	BookmarkedBy uint
+	AuthorID     uint
+}
+
+// Article row with the relevance score computed by the search query
+type ArticleSearchResult struct {
+	models.Article
+	Relevance float64 `json:"relevance"`
+}
+
 type ArticleRepository interface {
-	GetAllArticles(page, limit int) ([]models.Article, int, error)
+	GetAllArticles(filter ArticleFilter, page, limit int) ([]models.Article, int, error)
+	GetSitemapArticles(offset, limit int) ([]models.Article, int, error)
+	GetArticlesLikedByUser(userID uint) ([]models.Article, error)
+	GetFeedCandidates(userID uint, limit int) ([]models.Article, error)
+	SearchArticles(query string, filter ArticleFilter, page, limit int) ([]ArticleSearchResult, int, error)
 	GetArticleByID(id uint) (models.Article, error)
+	GetPublishedArticlesByIDs(ids []uint) ([]models.Article, error)
+	GetArticleBySlug(slug string) (models.Article, error)
+	GetArticleSlugHistory(slug string) (models.ArticleSlug, error)
+	IsSlugTaken(slug string, excludeID uint) (bool, error)
+	SaveArticleSlugHistory(articleID uint, slug string) error
 	GetArticleByImage(image string) (int64, error)
 	GetArticleByThumbnail(thumbnail string) (int64, error)
 	CreateArticle(article models.Article) (models.Article, error)
 	UpdateArticle(article models.Article) (models.Article, error)
+	ReplaceArticleTags(article models.Article, tags []models.Tag) error
+	PublishScheduledArticles(now time.Time) ([]models.Article, error)
+	IncrementArticleViews(views map[uint]int) error
+	MigrateArticleReadingStats() error
+	MigrateArticleDescriptionHTML() error
 	DeleteArticle(article models.Article) error
 }
 
@@ -25,32 +58,273 @@ func NewArticleRepository(db *gorm.DB) ArticleRepository {
 }
 
 // Get All Articles from DB with optional pagination
-func (r *articleRepository) GetAllArticles(page, limit int) ([]models.Article, int, error) {
+func (r *articleRepository) GetAllArticles(filter ArticleFilter, page, limit int) ([]models.Article, int, error) {
 	var (
 		articles []models.Article
 		count    int64
 	)
 
-	err := r.db.Model(&models.Article{}).Count(&count).Error
+	err := r.filterArticles(filter).Count(&count).Error
 	if err != nil {
 		return articles, int(count), err
 	}
 
 	offset := (page - 1) * limit
 
-	err = r.db.Model(&models.Article{}).Order("created_at desc").Limit(limit).Offset(offset).Find(&articles).Error
+	query := r.filterArticles(filter).Preload("Category").Preload("Tags")
+	if filter.BookmarkedBy != 0 {
+		// Reading list shows the latest saved articles first
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "(SELECT article_bookmarks.created_at FROM article_bookmarks WHERE article_bookmarks.article_id = articles.id AND article_bookmarks.user_id = ?) DESC, articles.id DESC",
+			Vars:               []interface{}{filter.BookmarkedBy},
+			WithoutParentheses: true,
+		}})
+	} else {
+		query = query.Order("published_at desc, created_at desc")
+	}
+
+	err = query.Limit(limit).Offset(offset).Find(&articles).Error
 
 	return articles, int(count), err
 }
 
+// Get published Articles for the sitemap, only the columns needed for the URL
+func (r *articleRepository) GetSitemapArticles(offset, limit int) ([]models.Article, int, error) {
+	var (
+		articles []models.Article
+		count    int64
+	)
+
+	published := ArticleFilter{Status: models.ArticlePublished}
+
+	err := r.filterArticles(published).Count(&count).Error
+	if err != nil || limit < 1 {
+		return articles, int(count), err
+	}
+
+	err = r.filterArticles(published).Select("id", "slug", "updated_at").Order("id asc").Limit(limit).Offset(offset).Find(&articles).Error
+
+	return articles, int(count), err
+}
+
+// Get Articles liked by the User with their Tags, used to learn the User interests
+func (r *articleRepository) GetArticlesLikedByUser(userID uint) ([]models.Article, error) {
+	var articles []models.Article
+
+	err := r.db.Preload("Tags").
+		Where("id IN (?)", r.db.Model(&models.ArticleLiked{}).Select("article_id").Where("user_id = ?", userID)).
+		Find(&articles).Error
+
+	return articles, err
+}
+
+// Get latest published Articles the User has not liked yet
+func (r *articleRepository) GetFeedCandidates(userID uint, limit int) ([]models.Article, error) {
+	var articles []models.Article
+
+	err := r.filterArticles(ArticleFilter{Status: models.ArticlePublished}).
+		Where("articles.id NOT IN (?)", r.db.Model(&models.ArticleLiked{}).Select("article_id").Where("user_id = ?", userID)).
+		Preload("Category").Preload("Tags").
+		Order("published_at desc, created_at desc").
+		Limit(limit).
+		Find(&articles).Error
+
+	return articles, err
+}
+
+// Build base article query with the optional filters
+func (r *articleRepository) filterArticles(filter ArticleFilter) *gorm.DB {
+	query := r.db.Model(&models.Article{})
+
+	if filter.Status != "" {
+		query = query.Where("articles.status = ?", filter.Status)
+	}
+
+	if filter.CategoryID != 0 {
+		query = query.Where("articles.category_id = ?", filter.CategoryID)
+	}
+
+	if filter.TagID != 0 {
+		query = query.Where("articles.id IN (?)", r.db.Table("article_tags").Select("article_id").Where("tag_id = ?", filter.TagID))
+	}
+
+	if filter.BookmarkedBy != 0 {
+		query = query.Where("articles.id IN (?)", r.db.Table("article_bookmarks").Select("article_id").Where("user_id = ?", filter.BookmarkedBy))
+	}
+
+	if filter.AuthorID != 0 {
+		query = query.Where("articles.administrator_id = ?", filter.AuthorID)
+	}
+
+	return query
+}
+
+// Search Articles by keyword over title, abstract, description and label.
+// MySQL uses the FULLTEXT index, other dialects fall back to a weighted LIKE score.
+func (r *articleRepository) SearchArticles(query string, filter ArticleFilter, page, limit int) ([]ArticleSearchResult, int, error) {
+	var (
+		results []ArticleSearchResult
+		count   int64
+	)
+
+	offset := (page - 1) * limit
+
+	if r.db.Dialector.Name() == "mysql" {
+		match := "MATCH(title, abstract, description, label) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+		err := r.filterArticles(filter).Where(match+" > 0", query).Count(&count).Error
+		if err != nil {
+			return results, int(count), err
+		}
+
+		err = r.filterArticles(filter).
+			Select("articles.*, "+match+" AS relevance", query).
+			Where(match+" > 0", query).
+			Order("relevance desc, published_at desc").
+			Limit(limit).Offset(offset).
+			Scan(&results).Error
+		if err != nil {
+			return results, int(count), err
+		}
+
+		return results, int(count), r.preloadSearchRelations(results)
+	}
+
+	// Same terms as the highlighted snippets
+	terms := helpers.SearchTerms(query)
+	if len(terms) == 0 {
+		return results, 0, nil
+	}
+
+	var (
+		conditions []string
+		scores     []string
+		condArgs   []interface{}
+		scoreArgs  []interface{}
+	)
+
+	// Title matches weigh the most, description the least
+	for _, term := range terms {
+		like := "%" + helpers.EscapeLike(term) + "%"
+		conditions = append(conditions, "(LOWER(title) LIKE ? ESCAPE '!' OR LOWER(abstract) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!' OR LOWER(label) LIKE ? ESCAPE '!')")
+		condArgs = append(condArgs, like, like, like, like)
+		scores = append(scores, "(CASE WHEN LOWER(title) LIKE ? ESCAPE '!' THEN 4 ELSE 0 END + CASE WHEN LOWER(label) LIKE ? ESCAPE '!' THEN 3 ELSE 0 END + CASE WHEN LOWER(abstract) LIKE ? ESCAPE '!' THEN 2 ELSE 0 END + CASE WHEN LOWER(description) LIKE ? ESCAPE '!' THEN 1 ELSE 0 END)")
+		scoreArgs = append(scoreArgs, like, like, like, like)
+	}
+
+	where := strings.Join(conditions, " OR ")
+
+	err := r.filterArticles(filter).Where(where, condArgs...).Count(&count).Error
+	if err != nil {
+		return results, int(count), err
+	}
+
+	err = r.filterArticles(filter).
+		Select("articles.*, ("+strings.Join(scores, " + ")+") AS relevance", scoreArgs...).
+		Where(where, condArgs...).
+		Order("relevance desc, published_at desc").
+		Limit(limit).Offset(offset).
+		Scan(&results).Error
+	if err != nil {
+		return results, int(count), err
+	}
+
+	return results, int(count), r.preloadSearchRelations(results)
+}
+
+// Scan does not support Preload, so relations of search results are loaded in one query
+func (r *articleRepository) preloadSearchRelations(results []ArticleSearchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	var ids []uint
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+
+	var articles []models.Article
+	err := r.db.Preload("Category").Preload("Tags").Where("id IN ?", ids).Find(&articles).Error
+	if err != nil {
+		return err
+	}
+
+	articleByID := map[uint]models.Article{}
+	for _, article := range articles {
+		articleByID[article.ID] = article
+	}
+
+	for i := range results {
+		if article, ok := articleByID[results[i].ID]; ok {
+			results[i].Category = article.Category
+			results[i].Tags = article.Tags
+		}
+	}
+
+	return nil
+}
+
 // Get Article By ID from DB
 func (r *articleRepository) GetArticleByID(id uint) (models.Article, error) {
 	var article models.Article
 
-	err := r.db.Where("id = ?", id).First(&article).Error
+	err := r.db.Preload("Category").Preload("Tags").Where("id = ?", id).First(&article).Error
+	return article, err
+}
+
+// Get published Articles by IDs, order of the result is not guaranteed
+func (r *articleRepository) GetPublishedArticlesByIDs(ids []uint) ([]models.Article, error) {
+	var articles []models.Article
+
+	if len(ids) == 0 {
+		return articles, nil
+	}
+
+	err := r.filterArticles(ArticleFilter{Status: models.ArticlePublished}).
+		Preload("Category").Preload("Tags").
+		Where("articles.id IN ?", ids).
+		Find(&articles).Error
+
+	return articles, err
+}
+
+// Get Article By current Slug from DB
+func (r *articleRepository) GetArticleBySlug(slug string) (models.Article, error) {
+	var article models.Article
+
+	err := r.db.Preload("Category").Preload("Tags").Where("slug = ?", slug).First(&article).Error
 	return article, err
 }
 
+// Get previous Slug of an Article to redirect old links
+func (r *articleRepository) GetArticleSlugHistory(slug string) (models.ArticleSlug, error) {
+	var articleSlug models.ArticleSlug
+
+	err := r.db.Where("slug = ?", slug).First(&articleSlug).Error
+	return articleSlug, err
+}
+
+// Check whether Slug is used by another Article, either as current or previous slug
+func (r *articleRepository) IsSlugTaken(slug string, excludeID uint) (bool, error) {
+	var total int64
+
+	// Deleted articles still hold their slug in the unique index
+	err := r.db.Unscoped().Model(&models.Article{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&total).Error
+	if err != nil || total > 0 {
+		return total > 0, err
+	}
+
+	err = r.db.Model(&models.ArticleSlug{}).Where("slug = ? AND article_id <> ?", slug, excludeID).Count(&total).Error
+	return total > 0, err
+}
+
+// Save previous Slug of the Article, saving the same slug twice is ignored
+func (r *articleRepository) SaveArticleSlugHistory(articleID uint, slug string) error {
+	articleSlug := models.ArticleSlug{ArticleID: articleID, Slug: slug}
+
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&articleSlug).Error
+}
+
 // Get Article by Image to validate and check the images is changes or not
 func (r *articleRepository) GetArticleByImage(image string) (int64, error) {
 	var (
@@ -81,18 +355,130 @@ func (r *articleRepository) GetArticleByThumbnail(thumbnail string) (int64, erro
 
 // Create Article and save to DB
 func (r *articleRepository) CreateArticle(article models.Article) (models.Article, error) {
-	err := r.db.Create(&article).Error
+	err := r.db.Omit(clause.Associations).Create(&article).Error
 
 	return article, err
 }
 
 // Update Article and save to DB
 func (r *articleRepository) UpdateArticle(article models.Article) (models.Article, error) {
-	err := r.db.Table("articles").Save(&article).Error
+	// Views are only written by IncrementArticleViews so a stale copy never resets them
+	err := r.db.Table("articles").Omit(clause.Associations, "Views").Save(&article).Error
 
 	return article, err
 }
 
+// Replace all Tags of the Article
+func (r *articleRepository) ReplaceArticleTags(article models.Article, tags []models.Tag) error {
+	if len(tags) == 0 {
+		return r.db.Model(&article).Association("Tags").Clear()
+	}
+
+	err := r.db.Model(&article).Association("Tags").Replace(tags)
+
+	return err
+}
+
+// Publish every article whose schedule is due. Rows are locked with SKIP LOCKED
+// so replicas running the scheduler at the same time never publish the same article twice.
+func (r *articleRepository) PublishScheduledArticles(now time.Time) ([]models.Article, error) {
+	var articles []models.Article
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("scheduled_at IS NOT NULL AND scheduled_at <= ? AND status IN ?", now, []string{models.ArticleDraft, models.ArticleInReview}).
+			Find(&articles).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range articles {
+			// Keep the first publishing date of articles that were published before
+			if articles[i].PublishedAt == nil {
+				publishedAt := *articles[i].ScheduledAt
+				articles[i].PublishedAt = &publishedAt
+			}
+			articles[i].Status = models.ArticlePublished
+			articles[i].ScheduledAt = nil
+
+			err = tx.Model(&articles[i]).Select("status", "published_at", "scheduled_at").Updates(&articles[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return articles, err
+}
+
+// Add buffered view counts to the Articles and today's view stats in one transaction,
+// updated_at of the article is left untouched
+func (r *articleRepository) IncrementArticleViews(views map[uint]int) error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for id, total := range views {
+			err := tx.Model(&models.Article{}).Where("id = ?", id).UpdateColumn("views", gorm.Expr("views + ?", total)).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "article_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("views + ?", total)}),
+			}).Create(&models.ArticleViewStat{ArticleID: id, Day: today, Views: total}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Fill word count and reading time of Articles saved before they were computed
+func (r *articleRepository) MigrateArticleReadingStats() error {
+	var articles []models.Article
+
+	return r.db.Select("id", "description").
+		Where("word_count = 0 AND description <> ''").
+		FindInBatches(&articles, 100, func(tx *gorm.DB, batch int) error {
+			for _, article := range articles {
+				words, minutes := helpers.ReadingStats(article.Description)
+
+				err := r.db.Model(&article).UpdateColumns(map[string]interface{}{"word_count": words, "reading_time": minutes}).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}).Error
+}
+
+// Render description of Articles saved before the rendered HTML was stored
+func (r *articleRepository) MigrateArticleDescriptionHTML() error {
+	var articles []models.Article
+
+	return r.db.Select("id", "description").
+		Where("(description_html IS NULL OR description_html = '') AND description <> ''").
+		FindInBatches(&articles, 100, func(tx *gorm.DB, batch int) error {
+			for _, article := range articles {
+				html, toc := helpers.RenderMarkdown(article.Description)
+
+				err := r.db.Model(&article).UpdateColumns(map[string]interface{}{"description_html": html, "table_of_contents": helpers.EncodeTOC(toc)}).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}).Error
+}
+
 // Delete Article from DB
 func (r *articleRepository) DeleteArticle(article models.Article) error {
 	err := r.db.Delete(&article).Error
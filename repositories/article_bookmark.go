@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArticleBookmarkRepository interface {
+	GetBookmark(userId uint, articleId uint) (models.ArticleBookmark, error)
+	CreateBookmark(bookmark models.ArticleBookmark) (models.ArticleBookmark, error)
+	DeleteBookmark(userId uint, articleId uint) error
+}
+
+type articleBookmarkRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleBookmarkRepository(db *gorm.DB) *articleBookmarkRepository {
+	return &articleBookmarkRepository{db}
+}
+
+func (r *articleBookmarkRepository) GetBookmark(userId uint, articleId uint) (models.ArticleBookmark, error) {
+	var bookmark models.ArticleBookmark
+
+	err := r.db.Where("user_id = ? AND article_id = ?", userId, articleId).First(&bookmark).Error
+
+	return bookmark, err
+}
+
+// Create bookmark, saving an article twice keeps the first bookmark
+func (r *articleBookmarkRepository) CreateBookmark(bookmark models.ArticleBookmark) (models.ArticleBookmark, error) {
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&bookmark).Error
+	if err != nil {
+		return bookmark, err
+	}
+
+	return r.GetBookmark(bookmark.UserID, bookmark.ArticleID)
+}
+
+func (r *articleBookmarkRepository) DeleteBookmark(userId uint, articleId uint) error {
+	return r.db.Where("user_id = ? AND article_id = ?", userId, articleId).Delete(&models.ArticleBookmark{}).Error
+}
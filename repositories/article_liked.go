@@ -4,13 +4,17 @@ import (
 	"go_bedu/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ArticleLikedRepository interface {
 	GetArticleLikedByUserId(userId uint) ([]models.ArticleLiked, error)
 	GetLikeByUserIdAndArticleId(userId uint, articleId uint) (models.ArticleLiked, error)
-	CreateArticleLiked(articleLiked models.ArticleLiked) (models.ArticleLiked, error)
+	CreateArticleLiked(articleLiked models.ArticleLiked) (models.ArticleLiked, bool, error)
 	DeleteArticleLiked(userId uint, articleId uint) (articleLiked models.ArticleLiked, err error)
+	CountArticleLikes(articleId uint) (int, error)
+	CountLikesByArticleIDs(articleIds []uint) (map[uint]int, error)
+	GetLikedArticleIDs(userId uint, articleIds []uint) (map[uint]bool, error)
 }
 
 type articleLikedRepository struct {
@@ -37,14 +41,76 @@ func (r *articleLikedRepository) GetArticleLikedByUserId(userId uint) ([]models.
 	return articleLiked, err
 }
 
-func (r *articleLikedRepository) CreateArticleLiked(articleLiked models.ArticleLiked) (models.ArticleLiked, error) {
-	err := r.db.Create(&articleLiked).Error
+// Like the article once, false when the user already liked it
+func (r *articleLikedRepository) CreateArticleLiked(articleLiked models.ArticleLiked) (models.ArticleLiked, bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&articleLiked)
 
-	return articleLiked, err
+	return articleLiked, result.RowsAffected == 1, result.Error
 }
 
+// Remove the like permanently, a soft deleted row would block the unique index when liking again
 func (r *articleLikedRepository) DeleteArticleLiked(userId uint, articleId uint) (articleLiked models.ArticleLiked, err error) {
-	err = r.db.Where("user_id = ? AND article_id = ?", userId, articleId).Delete(&articleLiked).Error
+	err = r.db.Unscoped().Where("user_id = ? AND article_id = ?", userId, articleId).Delete(&articleLiked).Error
 
 	return articleLiked, err
 }
+
+// Count users who like the article
+func (r *articleLikedRepository) CountArticleLikes(articleId uint) (int, error) {
+	var count int64
+
+	err := r.db.Model(&models.ArticleLiked{}).Where("article_id = ?", articleId).Count(&count).Error
+
+	return int(count), err
+}
+
+// Count likes of a page of articles in one grouped query
+func (r *articleLikedRepository) CountLikesByArticleIDs(articleIds []uint) (map[uint]int, error) {
+	var rows []struct {
+		ArticleID uint
+		Total     int
+	}
+
+	counts := map[uint]int{}
+	if len(articleIds) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.Model(&models.ArticleLiked{}).
+		Select("article_id, COUNT(*) AS total").
+		Where("article_id IN ?", articleIds).
+		Group("article_id").
+		Scan(&rows).Error
+	if err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.ArticleID] = row.Total
+	}
+
+	return counts, nil
+}
+
+// Get which of the given articles are liked by the user
+func (r *articleLikedRepository) GetLikedArticleIDs(userId uint, articleIds []uint) (map[uint]bool, error) {
+	var ids []uint
+
+	liked := map[uint]bool{}
+	if len(articleIds) == 0 {
+		return liked, nil
+	}
+
+	err := r.db.Model(&models.ArticleLiked{}).
+		Where("user_id = ? AND article_id IN ?", userId, articleIds).
+		Pluck("article_id", &ids).Error
+	if err != nil {
+		return liked, err
+	}
+
+	for _, id := range ids {
+		liked[id] = true
+	}
+
+	return liked, nil
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"go_bedu/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Likes and views of an article, per day or in total when Day is zero
+type ArticleActivity struct {
+	ArticleID uint
+	Day       time.Time
+	Likes     int
+	Views     int
+}
+
+type ArticleRankingRepository interface {
+	GetRankings(kind string, page, limit int) ([]models.ArticleRanking, int, error)
+	GetDailyLikes(since time.Time) ([]ArticleActivity, error)
+	GetDailyViews(since time.Time) ([]ArticleActivity, error)
+	GetMostLiked(since *time.Time, limit int) ([]ArticleActivity, error)
+	ReplaceRankings(kind string, rankings []models.ArticleRanking) error
+}
+
+type articleRankingRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleRankingRepository(db *gorm.DB) ArticleRankingRepository {
+	return &articleRankingRepository{db}
+}
+
+// Get cached Rankings of published Articles ordered by position
+func (r *articleRankingRepository) GetRankings(kind string, page, limit int) ([]models.ArticleRanking, int, error) {
+	var (
+		rankings []models.ArticleRanking
+		count    int64
+	)
+
+	query := func() *gorm.DB {
+		return r.db.Model(&models.ArticleRanking{}).
+			Joins("JOIN articles ON articles.id = article_rankings.article_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+			Where("article_rankings.kind = ?", kind)
+	}
+
+	err := query().Count(&count).Error
+	if err != nil {
+		return rankings, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query().Preload("Article").Preload("Article.Category").Preload("Article.Tags").
+		Order("article_rankings.position asc").Limit(limit).Offset(offset).Find(&rankings).Error
+
+	return rankings, int(count), err
+}
+
+// Get likes of published Articles grouped per day since the given time
+func (r *articleRankingRepository) GetDailyLikes(since time.Time) ([]ArticleActivity, error) {
+	var activities []ArticleActivity
+
+	err := r.likes().
+		Select("article_likeds.article_id, DATE(article_likeds.created_at) AS day, COUNT(*) AS likes").
+		Where("article_likeds.created_at >= ?", since).
+		Group("article_likeds.article_id, DATE(article_likeds.created_at)").
+		Scan(&activities).Error
+
+	return activities, err
+}
+
+// Get views of published Articles grouped per day since the given time
+func (r *articleRankingRepository) GetDailyViews(since time.Time) ([]ArticleActivity, error) {
+	var activities []ArticleActivity
+
+	err := r.db.Model(&models.ArticleViewStat{}).
+		Select("article_view_stats.article_id, article_view_stats.day, article_view_stats.views").
+		Joins("JOIN articles ON articles.id = article_view_stats.article_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+		Where("article_view_stats.day >= ?", since).
+		Scan(&activities).Error
+
+	return activities, err
+}
+
+// Get published Articles with the most likes, since is nil for all time
+func (r *articleRankingRepository) GetMostLiked(since *time.Time, limit int) ([]ArticleActivity, error) {
+	var activities []ArticleActivity
+
+	query := r.likes().Select("article_likeds.article_id, COUNT(*) AS likes")
+	if since != nil {
+		query = query.Where("article_likeds.created_at >= ?", *since)
+	}
+
+	err := query.Group("article_likeds.article_id").
+		Order("likes desc, article_likeds.article_id asc").
+		Limit(limit).
+		Scan(&activities).Error
+
+	return activities, err
+}
+
+// Replace every Ranking of the kind in one transaction so readers never see a partial ranking
+func (r *articleRankingRepository) ReplaceRankings(kind string, rankings []models.ArticleRanking) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("kind = ?", kind).Delete(&models.ArticleRanking{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(rankings) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Article").CreateInBatches(&rankings, 100).Error
+	})
+}
+
+// Base query of likes on published Articles
+func (r *articleRankingRepository) likes() *gorm.DB {
+	return r.db.Model(&models.ArticleLiked{}).
+		Joins("JOIN articles ON articles.id = article_likeds.article_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished)
+}
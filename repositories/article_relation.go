@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+)
+
+type ArticleRelationRepository interface {
+	GetRelatedArticles(articleID uint, limit int) ([]models.ArticleRelation, error)
+	GetRecommendationCorpus() ([]models.Article, error)
+	CountRelations() (int64, error)
+	GetAllRelations() ([]models.ArticleRelation, error)
+	SaveRelations(lists map[uint][]models.ArticleRelation) error
+}
+
+type articleRelationRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleRelationRepository(db *gorm.DB) ArticleRelationRepository {
+	return &articleRelationRepository{db}
+}
+
+// Get published related Articles ordered by similarity
+func (r *articleRelationRepository) GetRelatedArticles(articleID uint, limit int) ([]models.ArticleRelation, error) {
+	var relations []models.ArticleRelation
+
+	err := r.db.Joins("JOIN articles ON articles.id = article_relations.related_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+		Preload("Related").Preload("Related.Category").Preload("Related.Tags").
+		Where("article_relations.article_id = ?", articleID).
+		Order("article_relations.score desc").
+		Limit(limit).
+		Find(&relations).Error
+
+	return relations, err
+}
+
+// Get every published Article with the fields used to compute similarity
+func (r *articleRelationRepository) GetRecommendationCorpus() ([]models.Article, error) {
+	var articles []models.Article
+
+	err := r.db.Select("id", "category_id", "title", "abstract", "description").
+		Where("status = ?", models.ArticlePublished).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Select("tags.id")
+		}).
+		Find(&articles).Error
+
+	return articles, err
+}
+
+// Count stored Relations, zero means recommendations were never computed
+func (r *articleRelationRepository) CountRelations() (int64, error) {
+	var total int64
+
+	err := r.db.Model(&models.ArticleRelation{}).Count(&total).Error
+
+	return total, err
+}
+
+// Get every stored Relation, most similar first within each Article
+func (r *articleRelationRepository) GetAllRelations() ([]models.ArticleRelation, error) {
+	var relations []models.ArticleRelation
+
+	err := r.db.Select("article_id", "related_id", "score").Order("article_id asc, score desc").Find(&relations).Error
+
+	return relations, err
+}
+
+// Replace the related lists of the given Articles in one transaction, an empty list removes the Article relations
+func (r *articleRelationRepository) SaveRelations(lists map[uint][]models.ArticleRelation) error {
+	if len(lists) == 0 {
+		return nil
+	}
+
+	var (
+		articleIDs []uint
+		relations  []models.ArticleRelation
+	)
+	for articleID, list := range lists {
+		articleIDs = append(articleIDs, articleID)
+		relations = append(relations, list...)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id IN ?", articleIDs).Delete(&models.ArticleRelation{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(relations) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Article", "Related").CreateInBatches(&relations, 500).Error
+	})
+}
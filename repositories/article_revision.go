@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArticleRevisionRepository interface {
+	GetRevisions(articleId uint) ([]models.ArticleRevision, error)
+	GetRevision(articleId uint, revision int) (models.ArticleRevision, error)
+	CountRevisions(articleId uint) (int64, error)
+	CreateRevision(revision models.ArticleRevision) (models.ArticleRevision, error)
+}
+
+type articleRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleRevisionRepository(db *gorm.DB) ArticleRevisionRepository {
+	return &articleRevisionRepository{db}
+}
+
+// Get all Revisions of an article, newest first
+func (r *articleRevisionRepository) GetRevisions(articleId uint) ([]models.ArticleRevision, error) {
+	var revisions []models.ArticleRevision
+
+	err := r.db.Preload("Administrator").Where("article_id = ?", articleId).Order("revision desc").Find(&revisions).Error
+
+	return revisions, err
+}
+
+// Get single Revision of an article by its number
+func (r *articleRevisionRepository) GetRevision(articleId uint, revision int) (models.ArticleRevision, error) {
+	var articleRevision models.ArticleRevision
+
+	err := r.db.Preload("Administrator").Where("article_id = ? AND revision = ?", articleId, revision).First(&articleRevision).Error
+
+	return articleRevision, err
+}
+
+// Count Revisions of an article
+func (r *articleRevisionRepository) CountRevisions(articleId uint) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&models.ArticleRevision{}).Where("article_id = ?", articleId).Count(&count).Error
+
+	return count, err
+}
+
+// Create Revision with the next number of the article
+func (r *articleRevisionRepository) CreateRevision(revision models.ArticleRevision) (models.ArticleRevision, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+
+		err := tx.Model(&models.ArticleRevision{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("article_id = ?", revision.ArticleID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		revision.Revision = last + 1
+
+		return tx.Omit("Administrator").Create(&revision).Error
+	})
+
+	return revision, err
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"go_bedu/helpers"
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+)
+
+// Category row with the total of articles using it
+type CategoryWithCount struct {
+	models.Category
+	ArticleCount int `json:"article_count"`
+}
+
+type CategoryRepository interface {
+	GetCategories() ([]CategoryWithCount, error)
+	GetCategoryByID(id uint) (models.Category, error)
+	GetCategoryBySlug(slug string) (models.Category, error)
+	CountArticlesByCategory(id uint) (int64, error)
+	CreateCategory(category models.Category) (models.Category, error)
+	UpdateCategory(category models.Category) (models.Category, error)
+	DeleteCategory(category models.Category) error
+	MigrateArticleLabels() error
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{db}
+}
+
+// Get All Categories with their published article count
+func (r *categoryRepository) GetCategories() ([]CategoryWithCount, error) {
+	var categories []CategoryWithCount
+
+	err := r.db.Model(&models.Category{}).
+		Select("categories.*, COUNT(articles.id) AS article_count").
+		Joins("LEFT JOIN articles ON articles.category_id = categories.id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+		Group("categories.id").
+		Order("categories.name asc").
+		Scan(&categories).Error
+
+	return categories, err
+}
+
+// Get Category By ID from DB
+func (r *categoryRepository) GetCategoryByID(id uint) (models.Category, error) {
+	var category models.Category
+
+	err := r.db.Where("id = ?", id).First(&category).Error
+
+	return category, err
+}
+
+// Get Category By Slug from DB
+func (r *categoryRepository) GetCategoryBySlug(slug string) (models.Category, error) {
+	var category models.Category
+
+	err := r.db.Where("slug = ?", slug).First(&category).Error
+
+	return category, err
+}
+
+// Count Articles that still use the category
+func (r *categoryRepository) CountArticlesByCategory(id uint) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&models.Article{}).Where("category_id = ?", id).Count(&count).Error
+
+	return count, err
+}
+
+// Create Category and save to DB
+func (r *categoryRepository) CreateCategory(category models.Category) (models.Category, error) {
+	err := r.db.Create(&category).Error
+
+	return category, err
+}
+
+// Update Category and the label copied into its Articles, search and listings read the label
+func (r *categoryRepository) UpdateCategory(category models.Category) (models.Category, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Save(&category).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&models.Article{}).
+			Where("category_id = ?", category.ID).
+			UpdateColumn("label", category.Name).Error
+	})
+
+	return category, err
+}
+
+// Delete Category permanently so the slug can be used again
+func (r *categoryRepository) DeleteCategory(category models.Category) error {
+	err := r.db.Unscoped().Delete(&category).Error
+
+	return err
+}
+
+// Convert the free-text Article.Label into categories, labels with the same slug are merged
+func (r *categoryRepository) MigrateArticleLabels() error {
+	var labels []string
+
+	err := r.db.Model(&models.Article{}).
+		Where("category_id IS NULL AND label <> ''").
+		Distinct().
+		Pluck("label", &labels).Error
+	if err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		slug := helpers.CreateSlug(label)
+		if slug == "" {
+			continue
+		}
+
+		category := models.Category{Name: label, Slug: slug}
+		err = r.db.Where(models.Category{Slug: slug}).FirstOrCreate(&category).Error
+		if err != nil {
+			return err
+		}
+
+		err = r.db.Model(&models.Article{}).
+			Where("category_id IS NULL AND label = ?", label).
+			Updates(map[string]interface{}{"category_id": category.ID, "label": category.Name}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
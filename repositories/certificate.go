@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CertificateRepository interface {
+	GetCertificates(userId uint, page, limit int) ([]models.Certificate, int, error)
+	GetCertificate(userId uint, courseId uint) (models.Certificate, error)
+	GetCertificateByCode(code string) (models.Certificate, error)
+	CreateCertificate(certificate models.Certificate) (models.Certificate, error)
+}
+
+type certificateRepository struct {
+	db *gorm.DB
+}
+
+func NewCertificateRepository(db *gorm.DB) CertificateRepository {
+	return &certificateRepository{db}
+}
+
+// Get certificates of a user, latest issued first
+func (r *certificateRepository) GetCertificates(userId uint, page, limit int) ([]models.Certificate, int, error) {
+	var (
+		certificates []models.Certificate
+		count        int64
+	)
+
+	query := r.db.Model(&models.Certificate{}).Where("user_id = ?", userId)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return certificates, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.Order("issued_at DESC").Limit(limit).Offset(offset).Find(&certificates).Error
+
+	return certificates, int(count), err
+}
+
+func (r *certificateRepository) GetCertificate(userId uint, courseId uint) (models.Certificate, error) {
+	var certificate models.Certificate
+
+	err := r.db.Where("user_id = ? AND course_id = ?", userId, courseId).First(&certificate).Error
+
+	return certificate, err
+}
+
+func (r *certificateRepository) GetCertificateByCode(code string) (models.Certificate, error) {
+	var certificate models.Certificate
+
+	err := r.db.Where("verification_code = ?", code).First(&certificate).Error
+
+	return certificate, err
+}
+
+// Create certificate, a course completed again keeps the first certificate
+func (r *certificateRepository) CreateCertificate(certificate models.Certificate) (models.Certificate, error) {
+	err := r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}, {Name: "course_id"}}, DoNothing: true}).
+		Create(&certificate).Error
+	if err != nil {
+		return certificate, err
+	}
+
+	return r.GetCertificate(certificate.UserID, certificate.CourseID)
+}
@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CollectionRepository interface {
+	GetCollectionsByUser(userId uint, page, limit int) ([]models.Collection, int, error)
+	GetCollectionByID(id uint) (models.Collection, error)
+	GetCollectionByShareToken(token string) (models.Collection, error)
+	CountCollectionItems(collectionIds []uint) (map[uint]int, error)
+	GetCollectionItems(collectionId uint) ([]models.CollectionItem, error)
+	CreateCollection(collection models.Collection) (models.Collection, error)
+	UpdateCollection(collection models.Collection) (models.Collection, error)
+	DeleteCollection(collection models.Collection) error
+	AddCollectionItem(item models.CollectionItem) error
+	DeleteCollectionItem(collectionId uint, articleId uint) error
+	ReorderCollectionItems(collectionId uint, articleIds []uint) error
+}
+
+type collectionRepository struct {
+	db *gorm.DB
+}
+
+func NewCollectionRepository(db *gorm.DB) CollectionRepository {
+	return &collectionRepository{db}
+}
+
+// Get Collections of a user with pagination, latest first
+func (r *collectionRepository) GetCollectionsByUser(userId uint, page, limit int) ([]models.Collection, int, error) {
+	var (
+		collections []models.Collection
+		count       int64
+	)
+
+	query := r.db.Model(&models.Collection{}).Where("user_id = ?", userId)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return collections, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.Order("created_at desc").Limit(limit).Offset(offset).Find(&collections).Error
+
+	return collections, int(count), err
+}
+
+func (r *collectionRepository) GetCollectionByID(id uint) (models.Collection, error) {
+	var collection models.Collection
+
+	err := r.db.Where("id = ?", id).First(&collection).Error
+
+	return collection, err
+}
+
+func (r *collectionRepository) GetCollectionByShareToken(token string) (models.Collection, error) {
+	var collection models.Collection
+
+	err := r.db.Preload("User").Where("share_token = ?", token).First(&collection).Error
+
+	return collection, err
+}
+
+// Count published articles of each collection in one grouped query
+func (r *collectionRepository) CountCollectionItems(collectionIds []uint) (map[uint]int, error) {
+	var rows []struct {
+		CollectionID uint
+		Count        int
+	}
+
+	counts := map[uint]int{}
+	if len(collectionIds) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.Model(&models.CollectionItem{}).
+		Select("collection_items.collection_id, COUNT(*) AS count").
+		Joins("JOIN articles ON articles.id = collection_items.article_id AND articles.deleted_at IS NULL").
+		Where("collection_items.collection_id IN ? AND articles.status = ?", collectionIds, models.ArticlePublished).
+		Group("collection_items.collection_id").
+		Scan(&rows).Error
+	if err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.CollectionID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// Get items of a collection ordered by position
+func (r *collectionRepository) GetCollectionItems(collectionId uint) ([]models.CollectionItem, error) {
+	var items []models.CollectionItem
+
+	err := r.db.Where("collection_id = ?", collectionId).Order("position asc, id asc").Find(&items).Error
+
+	return items, err
+}
+
+func (r *collectionRepository) CreateCollection(collection models.Collection) (models.Collection, error) {
+	err := r.db.Create(&collection).Error
+
+	return collection, err
+}
+
+func (r *collectionRepository) UpdateCollection(collection models.Collection) (models.Collection, error) {
+	err := r.db.Omit(clause.Associations).Save(&collection).Error
+
+	return collection, err
+}
+
+// Delete Collection together with its items
+func (r *collectionRepository) DeleteCollection(collection models.Collection) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("collection_id = ?", collection.ID).Delete(&models.CollectionItem{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&collection).Error
+	})
+}
+
+// Append an article at the end of a collection, adding it twice keeps the first position
+func (r *collectionRepository) AddCollectionItem(item models.CollectionItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var position int
+		err := tx.Model(&models.CollectionItem{}).
+			Where("collection_id = ?", item.CollectionID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&position).Error
+		if err != nil {
+			return err
+		}
+
+		item.Position = position + 1
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&item).Error
+	})
+}
+
+func (r *collectionRepository) DeleteCollectionItem(collectionId uint, articleId uint) error {
+	return r.db.Where("collection_id = ? AND article_id = ?", collectionId, articleId).Delete(&models.CollectionItem{}).Error
+}
+
+// Set positions following the given article order
+func (r *collectionRepository) ReorderCollectionItems(collectionId uint, articleIds []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, articleId := range articleIds {
+			err := tx.Model(&models.CollectionItem{}).
+				Where("collection_id = ? AND article_id = ?", collectionId, articleId).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	GetRootComments(articleId uint, status string, page, limit int) ([]models.Comment, int, error)
+	GetReplies(articleId uint, status string) ([]models.Comment, error)
+	GetCommentsByStatus(status string, page, limit int) ([]models.Comment, int, error)
+	GetCommentByID(id uint) (models.Comment, error)
+	CountCommentsByArticleIDs(articleIds []uint, status string) (map[uint]int, error)
+	CreateComment(comment models.Comment) (models.Comment, error)
+	UpdateComment(comment models.Comment) (models.Comment, error)
+	DeleteComment(comment models.Comment) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db}
+}
+
+// Get top level Comments of an article with pagination
+func (r *commentRepository) GetRootComments(articleId uint, status string, page, limit int) ([]models.Comment, int, error) {
+	var (
+		comments []models.Comment
+		count    int64
+	)
+
+	query := r.db.Model(&models.Comment{}).Where("article_id = ? AND parent_id IS NULL AND status = ?", articleId, status)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return comments, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.Preload("User").Order("created_at desc").Limit(limit).Offset(offset).Find(&comments).Error
+
+	return comments, int(count), err
+}
+
+// Get every reply of an article, the tree is built by the usecase
+func (r *commentRepository) GetReplies(articleId uint, status string) ([]models.Comment, error) {
+	var comments []models.Comment
+
+	err := r.db.Preload("User").
+		Where("article_id = ? AND parent_id IS NOT NULL AND status = ?", articleId, status).
+		Order("created_at asc").
+		Find(&comments).Error
+
+	return comments, err
+}
+
+// Get Comments for the moderation queue
+func (r *commentRepository) GetCommentsByStatus(status string, page, limit int) ([]models.Comment, int, error) {
+	var (
+		comments []models.Comment
+		count    int64
+	)
+
+	query := r.db.Model(&models.Comment{}).Where("status = ?", status)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return comments, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.Preload("User").Preload("Article").Order("created_at asc").Limit(limit).Offset(offset).Find(&comments).Error
+
+	return comments, int(count), err
+}
+
+// Get Comment By ID from DB
+func (r *commentRepository) GetCommentByID(id uint) (models.Comment, error) {
+	var comment models.Comment
+
+	err := r.db.Preload("User").Where("id = ?", id).First(&comment).Error
+
+	return comment, err
+}
+
+// Count Comments of many articles with a single grouped query
+func (r *commentRepository) CountCommentsByArticleIDs(articleIds []uint, status string) (map[uint]int, error) {
+	var rows []struct {
+		ArticleID uint
+		Total     int
+	}
+
+	counts := map[uint]int{}
+	if len(articleIds) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.Model(&models.Comment{}).
+		Select("article_id, COUNT(*) AS total").
+		Where("article_id IN ? AND status = ?", articleIds, status).
+		Group("article_id").
+		Scan(&rows).Error
+	if err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.ArticleID] = row.Total
+	}
+
+	return counts, nil
+}
+
+// Create Comment and save to DB
+func (r *commentRepository) CreateComment(comment models.Comment) (models.Comment, error) {
+	err := r.db.Omit("Article", "User").Create(&comment).Error
+
+	return comment, err
+}
+
+// Update Comment and save to DB
+func (r *commentRepository) UpdateComment(comment models.Comment) (models.Comment, error) {
+	err := r.db.Omit("Article", "User").Save(&comment).Error
+
+	return comment, err
+}
+
+// Delete Comment together with all of its replies
+func (r *commentRepository) DeleteComment(comment models.Comment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := []uint{comment.ID}
+
+		for len(ids) > 0 {
+			var childIds []uint
+			err := tx.Model(&models.Comment{}).Where("parent_id IN ?", ids).Pluck("id", &childIds).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Where("id IN ?", ids).Delete(&models.Comment{}).Error
+			if err != nil {
+				return err
+			}
+
+			ids = childIds
+		}
+
+		return nil
+	})
+}
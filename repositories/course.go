@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"go_bedu/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CourseRepository interface {
+	GetCourses(publishedOnly bool, page, limit int) ([]models.Course, int, error)
+	GetCourseByID(id uint) (models.Course, error)
+	IsCourseSlugTaken(slug string, excludeID uint) (bool, error)
+	CreateCourse(course models.Course) (models.Course, error)
+	UpdateCourse(course models.Course) (models.Course, error)
+	DeleteCourse(course models.Course) error
+	GetEnrollment(userId uint, courseId uint) (models.CourseEnrollment, error)
+	GetEnrollments(userId uint, page, limit int) ([]models.CourseEnrollment, int, error)
+	GetEnrollmentsByArticle(userId uint, articleId uint) ([]models.CourseEnrollment, error)
+	CreateEnrollment(enrollment models.CourseEnrollment) (models.CourseEnrollment, error)
+	DeleteEnrollment(userId uint, courseId uint) error
+	MarkEnrollmentCompleted(enrollment models.CourseEnrollment, completedAt time.Time) (bool, error)
+	GetLessonCompletions(userId uint, courseIds []uint) ([]models.CourseLessonCompletion, error)
+	SaveLessonCompletions(completions []models.CourseLessonCompletion) error
+	GetCompletedReadings(userId uint, articleIds []uint) (map[uint]time.Time, error)
+	GetPassedQuizzes(userId uint, articleIds []uint) (map[uint]time.Time, error)
+}
+
+type courseRepository struct {
+	db *gorm.DB
+}
+
+func NewCourseRepository(db *gorm.DB) CourseRepository {
+	return &courseRepository{db}
+}
+
+func orderedLessons(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc")
+}
+
+// Get Courses with lessons, latest first
+func (r *courseRepository) GetCourses(publishedOnly bool, page, limit int) ([]models.Course, int, error) {
+	var (
+		courses []models.Course
+		count   int64
+	)
+
+	query := r.db.Model(&models.Course{})
+	if publishedOnly {
+		query = query.Where("published = ?", true)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return courses, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.Preload("Lessons", orderedLessons).Preload("Lessons.Article").
+		Order("created_at desc").
+		Limit(limit).Offset(offset).
+		Find(&courses).Error
+
+	return courses, int(count), err
+}
+
+// Get Course with its lessons in order
+func (r *courseRepository) GetCourseByID(id uint) (models.Course, error) {
+	var course models.Course
+
+	err := r.db.Preload("Lessons", orderedLessons).Preload("Lessons.Article").Where("id = ?", id).First(&course).Error
+
+	return course, err
+}
+
+// Slug is taken by any other course, including deleted ones
+func (r *courseRepository) IsCourseSlugTaken(slug string, excludeID uint) (bool, error) {
+	var count int64
+
+	err := r.db.Unscoped().Model(&models.Course{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+
+	return count > 0, err
+}
+
+// Create Course together with its lessons
+func (r *courseRepository) CreateCourse(course models.Course) (models.Course, error) {
+	err := r.db.Omit("Lessons.Article").Create(&course).Error
+	if err != nil {
+		return course, err
+	}
+
+	return r.GetCourseByID(course.ID)
+}
+
+// Update Course and replace its lessons
+func (r *courseRepository) UpdateCourse(course models.Course) (models.Course, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("course_id = ?", course.ID).Delete(&models.CourseLesson{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range course.Lessons {
+			course.Lessons[i].ID = 0
+			course.Lessons[i].CourseID = course.ID
+		}
+
+		err = tx.Omit(clause.Associations).Save(&course).Error
+		if err != nil {
+			return err
+		}
+
+		if len(course.Lessons) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Article").Create(&course.Lessons).Error
+	})
+	if err != nil {
+		return course, err
+	}
+
+	return r.GetCourseByID(course.ID)
+}
+
+func (r *courseRepository) DeleteCourse(course models.Course) error {
+	return r.db.Delete(&course).Error
+}
+
+func (r *courseRepository) GetEnrollment(userId uint, courseId uint) (models.CourseEnrollment, error) {
+	var enrollment models.CourseEnrollment
+
+	err := r.db.Where("user_id = ? AND course_id = ?", userId, courseId).First(&enrollment).Error
+
+	return enrollment, err
+}
+
+// Get enrollments of a user in courses that still exist, unfinished courses first
+func (r *courseRepository) GetEnrollments(userId uint, page, limit int) ([]models.CourseEnrollment, int, error) {
+	var (
+		enrollments []models.CourseEnrollment
+		count       int64
+	)
+
+	query := r.db.Model(&models.CourseEnrollment{}).
+		Joins("JOIN courses ON courses.id = course_enrollments.course_id AND courses.deleted_at IS NULL").
+		Where("course_enrollments.user_id = ?", userId)
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return enrollments, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.
+		Preload("Course").Preload("Course.Lessons", orderedLessons).Preload("Course.Lessons.Article").
+		Order("course_enrollments.completed_at IS NULL DESC, course_enrollments.created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&enrollments).Error
+
+	return enrollments, int(count), err
+}
+
+// Get enrollments of a user in courses having the article as lesson
+func (r *courseRepository) GetEnrollmentsByArticle(userId uint, articleId uint) ([]models.CourseEnrollment, error) {
+	var enrollments []models.CourseEnrollment
+
+	err := r.db.
+		Joins("JOIN courses ON courses.id = course_enrollments.course_id AND courses.deleted_at IS NULL").
+		Where("course_enrollments.user_id = ? AND course_enrollments.course_id IN (?)", userId,
+			r.db.Model(&models.CourseLesson{}).Select("course_id").Where("article_id = ?", articleId)).
+		Preload("Course").Preload("Course.Lessons", orderedLessons).Preload("Course.Lessons.Article").
+		Find(&enrollments).Error
+
+	return enrollments, err
+}
+
+// Enroll user, enrolling twice keeps the first enrollment
+func (r *courseRepository) CreateEnrollment(enrollment models.CourseEnrollment) (models.CourseEnrollment, error) {
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&enrollment).Error
+	if err != nil {
+		return enrollment, err
+	}
+
+	return r.GetEnrollment(enrollment.UserID, enrollment.CourseID)
+}
+
+// Delete enrollment together with the lesson progress of the course
+func (r *courseRepository) DeleteEnrollment(userId uint, courseId uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND course_id = ?", userId, courseId).Delete(&models.CourseLessonCompletion{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ? AND course_id = ?", userId, courseId).Delete(&models.CourseEnrollment{}).Error
+	})
+}
+
+// Set completion time once, false when the course was already completed
+func (r *courseRepository) MarkEnrollmentCompleted(enrollment models.CourseEnrollment, completedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.CourseEnrollment{}).
+		Where("id = ? AND completed_at IS NULL", enrollment.ID).
+		UpdateColumn("completed_at", completedAt)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *courseRepository) GetLessonCompletions(userId uint, courseIds []uint) ([]models.CourseLessonCompletion, error) {
+	var completions []models.CourseLessonCompletion
+
+	if len(courseIds) == 0 {
+		return completions, nil
+	}
+
+	err := r.db.Where("user_id = ? AND course_id IN ?", userId, courseIds).Find(&completions).Error
+
+	return completions, err
+}
+
+// Save completed lessons, lessons completed before keep their first completion time
+func (r *courseRepository) SaveLessonCompletions(completions []models.CourseLessonCompletion) error {
+	if len(completions) == 0 {
+		return nil
+	}
+
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&completions).Error
+}
+
+// Get when the user finished reading each of the articles
+func (r *courseRepository) GetCompletedReadings(userId uint, articleIds []uint) (map[uint]time.Time, error) {
+	var histories []models.ReadingHistory
+
+	completed := map[uint]time.Time{}
+	if len(articleIds) == 0 {
+		return completed, nil
+	}
+
+	err := r.db.Where("user_id = ? AND article_id IN ? AND completed_at IS NOT NULL", userId, articleIds).Find(&histories).Error
+	if err != nil {
+		return completed, err
+	}
+
+	for _, history := range histories {
+		completed[history.ArticleID] = *history.CompletedAt
+	}
+
+	return completed, nil
+}
+
+// Get when the user first passed the quiz of each of the articles
+func (r *courseRepository) GetPassedQuizzes(userId uint, articleIds []uint) (map[uint]time.Time, error) {
+	var rows []struct {
+		ArticleID uint
+		PassedAt  time.Time
+	}
+
+	passed := map[uint]time.Time{}
+	if len(articleIds) == 0 {
+		return passed, nil
+	}
+
+	err := r.db.Model(&models.QuizAttempt{}).
+		Select("article_id, MIN(created_at) AS passed_at").
+		Where("user_id = ? AND article_id IN ? AND passed = ?", userId, articleIds, true).
+		Group("article_id").
+		Scan(&rows).Error
+	if err != nil {
+		return passed, err
+	}
+
+	for _, row := range rows {
+		passed[row.ArticleID] = row.PassedAt
+	}
+
+	return passed, nil
+}
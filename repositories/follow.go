@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FollowRepository interface {
+	FollowAuthor(userId uint, adminId uint) error
+	UnfollowAuthor(userId uint, adminId uint) error
+	FollowCategory(userId uint, categoryId uint) error
+	UnfollowCategory(userId uint, categoryId uint) error
+	IsFollowingAuthor(userId uint, adminId uint) (bool, error)
+	IsFollowingCategory(userId uint, categoryId uint) (bool, error)
+	GetFollowedAuthors(userId uint) ([]models.AuthorFollow, error)
+	GetFollowedCategories(userId uint) ([]models.CategoryFollow, error)
+	CountAuthorFollowers(adminIds []uint) (map[uint]int, error)
+	CountCategoryFollowers(categoryIds []uint) (map[uint]int, error)
+	GetAuthorFollowerIDs(adminId uint) ([]uint, error)
+	GetCategoryFollowerIDs(categoryId uint) ([]uint, error)
+}
+
+type followRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepository{db}
+}
+
+// Following twice keeps the first follow
+func (r *followRepository) FollowAuthor(userId uint, adminId uint) error {
+	follow := models.AuthorFollow{UserID: userId, AdministratorID: adminId}
+
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&follow).Error
+}
+
+func (r *followRepository) UnfollowAuthor(userId uint, adminId uint) error {
+	return r.db.Where("user_id = ? AND administrator_id = ?", userId, adminId).Delete(&models.AuthorFollow{}).Error
+}
+
+// Following twice keeps the first follow
+func (r *followRepository) FollowCategory(userId uint, categoryId uint) error {
+	follow := models.CategoryFollow{UserID: userId, CategoryID: categoryId}
+
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&follow).Error
+}
+
+func (r *followRepository) UnfollowCategory(userId uint, categoryId uint) error {
+	return r.db.Where("user_id = ? AND category_id = ?", userId, categoryId).Delete(&models.CategoryFollow{}).Error
+}
+
+func (r *followRepository) IsFollowingAuthor(userId uint, adminId uint) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&models.AuthorFollow{}).Where("user_id = ? AND administrator_id = ?", userId, adminId).Count(&count).Error
+
+	return count > 0, err
+}
+
+func (r *followRepository) IsFollowingCategory(userId uint, categoryId uint) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&models.CategoryFollow{}).Where("user_id = ? AND category_id = ?", userId, categoryId).Count(&count).Error
+
+	return count > 0, err
+}
+
+// Get followed authors that still exist, latest followed first
+func (r *followRepository) GetFollowedAuthors(userId uint) ([]models.AuthorFollow, error) {
+	var follows []models.AuthorFollow
+
+	err := r.db.
+		Joins("JOIN administrators ON administrators.id = author_follows.administrator_id AND administrators.deleted_at IS NULL").
+		Where("author_follows.user_id = ?", userId).
+		Preload("Administrator").
+		Order("author_follows.created_at DESC").
+		Find(&follows).Error
+
+	return follows, err
+}
+
+// Get followed categories that still exist, latest followed first
+func (r *followRepository) GetFollowedCategories(userId uint) ([]models.CategoryFollow, error) {
+	var follows []models.CategoryFollow
+
+	err := r.db.
+		Joins("JOIN categories ON categories.id = category_follows.category_id AND categories.deleted_at IS NULL").
+		Where("category_follows.user_id = ?", userId).
+		Preload("Category").
+		Order("category_follows.created_at DESC").
+		Find(&follows).Error
+
+	return follows, err
+}
+
+func (r *followRepository) CountAuthorFollowers(adminIds []uint) (map[uint]int, error) {
+	return r.countFollowers("author_follows", "administrator_id", adminIds)
+}
+
+func (r *followRepository) CountCategoryFollowers(categoryIds []uint) (map[uint]int, error) {
+	return r.countFollowers("category_follows", "category_id", categoryIds)
+}
+
+// Count followers of each id, users who deleted their account are left out
+func (r *followRepository) countFollowers(table string, column string, ids []uint) (map[uint]int, error) {
+	var rows []struct {
+		ID        uint
+		Followers int
+	}
+
+	counts := map[uint]int{}
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.Table(table).
+		Select(table+"."+column+" AS id, COUNT(*) AS followers").
+		Joins("JOIN users ON users.id = "+table+".user_id AND users.deleted_at IS NULL").
+		Where(table+"."+column+" IN ?", ids).
+		Group(table + "." + column).
+		Scan(&rows).Error
+	if err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.ID] = row.Followers
+	}
+
+	return counts, nil
+}
+
+func (r *followRepository) GetAuthorFollowerIDs(adminId uint) ([]uint, error) {
+	var userIds []uint
+
+	err := r.db.Model(&models.AuthorFollow{}).Where("administrator_id = ?", adminId).Pluck("user_id", &userIds).Error
+
+	return userIds, err
+}
+
+func (r *followRepository) GetCategoryFollowerIDs(categoryId uint) ([]uint, error) {
+	var userIds []uint
+
+	err := r.db.Model(&models.CategoryFollow{}).Where("category_id = ?", categoryId).Pluck("user_id", &userIds).Error
+
+	return userIds, err
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import "gorm.io/gorm"
+
+type HealthRepository interface {
+	Ping() error
+}
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository(db *gorm.DB) HealthRepository {
+	return &healthRepository{db}
+}
+
+// Check database connection is still alive
+func (r *healthRepository) Ping() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Ping()
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"go_bedu/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	GetNotifications(userId uint, unreadOnly bool, page, limit int) ([]models.Notification, int, error)
+	CreateNotifications(notifications []models.Notification) error
+	MarkNotificationRead(userId uint, id uint, readAt time.Time) error
+	MarkAllNotificationsRead(userId uint, readAt time.Time) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db}
+}
+
+// Notifications of articles that are still published, latest first
+func (r *notificationRepository) notificationQuery(userId uint) *gorm.DB {
+	return r.db.Model(&models.Notification{}).
+		Joins("JOIN articles ON articles.id = notifications.article_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+		Where("notifications.user_id = ?", userId)
+}
+
+func (r *notificationRepository) GetNotifications(userId uint, unreadOnly bool, page, limit int) ([]models.Notification, int, error) {
+	var (
+		notifications []models.Notification
+		count         int64
+	)
+
+	query := r.notificationQuery(userId)
+	if unreadOnly {
+		query = query.Where("notifications.read_at IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return notifications, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.Preload("Article").
+		Order("notifications.created_at DESC, notifications.id DESC").
+		Limit(limit).Offset(offset).
+		Find(&notifications).Error
+
+	return notifications, int(count), err
+}
+
+// Create notifications, users already notified of the article are skipped
+func (r *notificationRepository) CreateNotifications(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&notifications, 100).Error
+}
+
+func (r *notificationRepository) MarkNotificationRead(userId uint, id uint, readAt time.Time) error {
+	var notification models.Notification
+
+	err := r.db.Where("id = ? AND user_id = ?", id, userId).First(&notification).Error
+	if err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	return r.db.Model(&notification).UpdateColumn("read_at", readAt).Error
+}
+
+func (r *notificationRepository) MarkAllNotificationsRead(userId uint, readAt time.Time) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		UpdateColumn("read_at", readAt).Error
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+)
+
+// Best result of a user on the quiz of an article
+type QuizScore struct {
+	ArticleID    uint
+	ArticleTitle string
+	BestScore    int
+	Attempts     int
+	Passed       bool
+}
+
+type QuizRepository interface {
+	GetQuizByArticleID(articleId uint) (models.Quiz, error)
+	SaveQuiz(quiz models.Quiz) (models.Quiz, error)
+	DeleteQuiz(articleId uint) error
+	CreateQuizAttempt(attempt models.QuizAttempt) (models.QuizAttempt, error)
+	GetBestQuizScore(userId uint, articleId uint) (int, error)
+	HasPassedQuiz(userId uint, articleId uint) (bool, error)
+	GetQuizScores(userId uint) ([]QuizScore, error)
+}
+
+type quizRepository struct {
+	db *gorm.DB
+}
+
+func NewQuizRepository(db *gorm.DB) QuizRepository {
+	return &quizRepository{db}
+}
+
+// Get Quiz of an article with questions and options in order
+func (r *quizRepository) GetQuizByArticleID(articleId uint) (models.Quiz, error) {
+	var quiz models.Quiz
+
+	err := r.db.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc")
+		}).
+		Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc")
+		}).
+		Where("article_id = ?", articleId).
+		First(&quiz).Error
+
+	return quiz, err
+}
+
+// Create or replace the Quiz of an article, old questions are removed
+func (r *quizRepository) SaveQuiz(quiz models.Quiz) (models.Quiz, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.Quiz
+		err := tx.Where("article_id = ?", quiz.ArticleID).Limit(1).Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if existing.ID != 0 {
+			err = deleteQuizQuestions(tx, existing.ID)
+			if err != nil {
+				return err
+			}
+			quiz.ID = existing.ID
+			quiz.CreatedAt = existing.CreatedAt
+		}
+
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Omit("Article").Save(&quiz).Error
+	})
+	if err != nil {
+		return quiz, err
+	}
+
+	return r.GetQuizByArticleID(quiz.ArticleID)
+}
+
+// Delete Quiz of an article, attempts are kept for the profile scores
+func (r *quizRepository) DeleteQuiz(articleId uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var quiz models.Quiz
+		err := tx.Where("article_id = ?", articleId).First(&quiz).Error
+		if err != nil {
+			return err
+		}
+
+		err = deleteQuizQuestions(tx, quiz.ID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&quiz).Error
+	})
+}
+
+func deleteQuizQuestions(tx *gorm.DB, quizId uint) error {
+	err := tx.Where("question_id IN (?)", tx.Model(&models.QuizQuestion{}).Select("id").Where("quiz_id = ?", quizId)).
+		Delete(&models.QuizOption{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Where("quiz_id = ?", quizId).Delete(&models.QuizQuestion{}).Error
+}
+
+// Create Quiz Attempt together with its answers
+func (r *quizRepository) CreateQuizAttempt(attempt models.QuizAttempt) (models.QuizAttempt, error) {
+	err := r.db.Omit("User", "Article").Create(&attempt).Error
+
+	return attempt, err
+}
+
+func (r *quizRepository) GetBestQuizScore(userId uint, articleId uint) (int, error) {
+	var best int
+
+	err := r.db.Model(&models.QuizAttempt{}).
+		Select("COALESCE(MAX(score), 0)").
+		Where("user_id = ? AND article_id = ?", userId, articleId).
+		Scan(&best).Error
+
+	return best, err
+}
+
+func (r *quizRepository) HasPassedQuiz(userId uint, articleId uint) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&models.QuizAttempt{}).
+		Where("user_id = ? AND article_id = ? AND passed = ?", userId, articleId, true).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// Get best score of every quiz the user attempted, latest attempted first
+func (r *quizRepository) GetQuizScores(userId uint) ([]QuizScore, error) {
+	var scores []QuizScore
+
+	err := r.db.Model(&models.QuizAttempt{}).
+		Select("quiz_attempts.article_id, articles.title AS article_title, MAX(quiz_attempts.score) AS best_score, COUNT(*) AS attempts, MAX(quiz_attempts.passed) AS passed").
+		Joins("JOIN articles ON articles.id = quiz_attempts.article_id AND articles.deleted_at IS NULL").
+		Where("quiz_attempts.user_id = ?", userId).
+		Group("quiz_attempts.article_id, articles.title").
+		Order("MAX(quiz_attempts.created_at) desc").
+		Scan(&scores).Error
+
+	return scores, err
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"go_bedu/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReadingHistoryRepository interface {
+	GetReadingHistories(userId uint, completed *bool, page, limit int) ([]models.ReadingHistory, int, error)
+	SaveReadingProgress(userId uint, articleId uint, progress int, readAt time.Time) (models.ReadingHistory, error)
+	MarkReadingCompleted(history models.ReadingHistory, completedAt time.Time) (bool, error)
+	SaveReadingActivity(userId uint, readAt time.Time) error
+	DeleteReadingHistory(userId uint, articleId uint) error
+	DeleteReadingHistories(userId uint) error
+}
+
+type readingHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewReadingHistoryRepository(db *gorm.DB) ReadingHistoryRepository {
+	return &readingHistoryRepository{db}
+}
+
+// Get reading history of published articles, unfinished articles first then latest read
+func (r *readingHistoryRepository) GetReadingHistories(userId uint, completed *bool, page, limit int) ([]models.ReadingHistory, int, error) {
+	var (
+		histories []models.ReadingHistory
+		count     int64
+	)
+
+	query := r.db.Model(&models.ReadingHistory{}).
+		Joins("JOIN articles ON articles.id = reading_histories.article_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+		Where("reading_histories.user_id = ?", userId)
+
+	if completed != nil && *completed {
+		query = query.Where("reading_histories.completed_at IS NOT NULL")
+	} else if completed != nil {
+		query = query.Where("reading_histories.completed_at IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return histories, int(count), err
+	}
+
+	offset := (page - 1) * limit
+
+	err = query.
+		Order("reading_histories.completed_at IS NULL DESC, reading_histories.last_read_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&histories).Error
+
+	return histories, int(count), err
+}
+
+// Save the latest reading position, the first report creates the history
+func (r *readingHistoryRepository) SaveReadingProgress(userId uint, articleId uint, progress int, readAt time.Time) (models.ReadingHistory, error) {
+	history := models.ReadingHistory{
+		UserID:     userId,
+		ArticleID:  articleId,
+		Progress:   progress,
+		LastReadAt: readAt,
+	}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "article_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"progress", "last_read_at", "updated_at"}),
+	}).Create(&history).Error
+	if err != nil {
+		return history, err
+	}
+
+	err = r.db.Where("user_id = ? AND article_id = ?", userId, articleId).First(&history).Error
+
+	return history, err
+}
+
+// Record a reading day of the user, later reads of the same day only move the last read time
+func (r *readingHistoryRepository) SaveReadingActivity(userId uint, readAt time.Time) error {
+	activity := models.ReadingActivity{
+		UserID:      userId,
+		Day:         readAt.UTC().Format("2006-01-02"),
+		FirstReadAt: readAt,
+		LastReadAt:  readAt,
+	}
+
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "day"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_read_at"}),
+	}).Create(&activity).Error
+}
+
+// Set completion time once, false when the article was already completed
+func (r *readingHistoryRepository) MarkReadingCompleted(history models.ReadingHistory, completedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.ReadingHistory{}).
+		Where("id = ? AND completed_at IS NULL", history.ID).
+		UpdateColumn("completed_at", completedAt)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *readingHistoryRepository) DeleteReadingHistory(userId uint, articleId uint) error {
+	return r.db.Where("user_id = ? AND article_id = ?", userId, articleId).Delete(&models.ReadingHistory{}).Error
+}
+
+func (r *readingHistoryRepository) DeleteReadingHistories(userId uint) error {
+	return r.db.Where("user_id = ?", userId).Delete(&models.ReadingHistory{}).Error
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"go_bedu/models"
+
+	"gorm.io/gorm"
+)
+
+// Tag row with the total of articles using it
+type TagWithCount struct {
+	models.Tag
+	ArticleCount int `json:"article_count"`
+}
+
+type TagRepository interface {
+	GetTagCloud(limit int) ([]TagWithCount, error)
+	GetTagBySlug(slug string) (models.Tag, error)
+	FirstOrCreateTag(tag models.Tag) (models.Tag, error)
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db}
+}
+
+// Get most used Tags with their published article count
+func (r *tagRepository) GetTagCloud(limit int) ([]TagWithCount, error) {
+	var tags []TagWithCount
+
+	err := r.db.Model(&models.Tag{}).
+		Select("tags.*, COUNT(articles.id) AS article_count").
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL AND articles.status = ?", models.ArticlePublished).
+		Group("tags.id").
+		Order("article_count desc, tags.name asc").
+		Limit(limit).
+		Scan(&tags).Error
+
+	return tags, err
+}
+
+// Get Tag By Slug from DB
+func (r *tagRepository) GetTagBySlug(slug string) (models.Tag, error) {
+	var tag models.Tag
+
+	err := r.db.Where("slug = ?", slug).First(&tag).Error
+
+	return tag, err
+}
+
+// Get Tag with the same slug or create it on first use
+func (r *tagRepository) FirstOrCreateTag(tag models.Tag) (models.Tag, error) {
+	err := r.db.Where(models.Tag{Slug: tag.Slug}).FirstOrCreate(&tag).Error
+
+	return tag, err
+}
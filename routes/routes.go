@@ -2,11 +2,14 @@ package routes
 
 import (
 	"go_bedu/controllers"
+	"go_bedu/initializers"
 	m "go_bedu/middlewares"
 	"go_bedu/repositories"
 	"go_bedu/usecase"
 	"go_bedu/utils"
 	"net/http"
+	"os"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -14,26 +17,115 @@ import (
 	"gorm.io/gorm"
 )
 
-func NewRoute(e *echo.Echo, db *gorm.DB) {
+func NewRoute(e *echo.Echo, db *gorm.DB, publishScheduler usecase.PublishScheduler, viewCounter usecase.ViewCounter, articleRecommender usecase.ArticleRecommender) {
+	// Read once for the usecases that build links to the client
+	config, _ := initializers.LoadConfig(".")
+
 	adminRepository := repositories.NewAdminRepository(db)
 	adminUsecase := usecase.NewAdminUsecase(adminRepository)
 	adminController := controllers.NewAdminController(adminUsecase, adminRepository)
 
+	categoryRepository := repositories.NewCategoryRepository(db)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepository)
+	categoryController := controllers.NewCategoryController(categoryUsecase)
+
+	tagRepository := repositories.NewTagRepository(db)
+	commentRepository := repositories.NewCommentRepository(db)
+	articleRevisionRepository := repositories.NewArticleRevisionRepository(db)
+	articleRankingRepository := repositories.NewArticleRankingRepository(db)
+	articleLiked := repositories.NewArticleLikedRepository(db)
+	followRepository := repositories.NewFollowRepository(db)
+
 	articleRepository := repositories.NewArticleRepository(db)
-	articleUsecase := usecase.NewArticleUsecase(articleRepository)
-	articleController := controllers.NewArticleController(articleUsecase)
+	articleUsecase := usecase.NewArticleUsecase(articleRepository, categoryRepository, tagRepository, commentRepository, articleRevisionRepository, articleRankingRepository, articleLiked, followRepository, articleRecommender)
+	articleController := controllers.NewArticleController(articleUsecase, viewCounter)
+
+	articleRevisionUsecase := usecase.NewArticleRevisionUsecase(articleRevisionRepository, articleRepository, categoryRepository, articleUsecase)
+	articleRevisionController := controllers.NewArticleRevisionController(articleRevisionUsecase)
+
+	tagUsecase := usecase.NewTagUsecase(tagRepository, articleUsecase)
+	tagController := controllers.NewTagController(tagUsecase, articleUsecase)
+
+	feedItemLimit, err := strconv.Atoi(os.Getenv("FEED_ITEM_LIMIT"))
+	if err != nil || feedItemLimit < 1 {
+		feedItemLimit = 20
+	}
+	feedUsecase := usecase.NewFeedUsecase(articleUsecase, categoryRepository, tagRepository, feedItemLimit)
+	feedController := controllers.NewFeedController(feedUsecase)
+
+	sitemapUsecase := usecase.NewSitemapUsecase(articleRepository, categoryRepository)
+	sitemapController := controllers.NewSitemapController(sitemapUsecase)
 
 	userRepository := repositories.NewUserRepository(db)
-	userUsecase := usecase.NewUserUsecase(userRepository)
+	quizRepository := repositories.NewQuizRepository(db)
+	userUsecase := usecase.NewUserUsecase(userRepository, quizRepository)
 	userController := controllers.NewUserControllers(userUsecase, userRepository)
 
-	articleLiked := repositories.NewArticleLikedRepository(db)
+	commentUsecase := usecase.NewCommentUsecase(commentRepository, articleRepository, userRepository)
+	commentController := controllers.NewCommentController(commentUsecase)
+
 	articleLikedUsecase := usecase.NewArticleLikedUsecase(articleLiked, userRepository)
 	articleLikedController := controllers.NewArticleLikedControllers(articleLikedUsecase, articleUsecase)
 
+	articleBookmarkRepository := repositories.NewArticleBookmarkRepository(db)
+	articleBookmarkUsecase := usecase.NewArticleBookmarkUsecase(articleBookmarkRepository, articleUsecase)
+	articleBookmarkController := controllers.NewArticleBookmarkControllers(articleBookmarkUsecase, articleUsecase)
+
+	collectionRepository := repositories.NewCollectionRepository(db)
+	collectionUsecase := usecase.NewCollectionUsecase(collectionRepository, articleUsecase, config.ClientOrigin)
+	collectionController := controllers.NewCollectionController(collectionUsecase, articleUsecase)
+
+	readingHistoryRepository := repositories.NewReadingHistoryRepository(db)
+	readingHistoryUsecase := usecase.NewReadingHistoryUsecase(readingHistoryRepository, articleUsecase)
+	readingHistoryController := controllers.NewReadingHistoryController(readingHistoryUsecase)
+
+	quizUsecase := usecase.NewQuizUsecase(quizRepository, articleRepository)
+	quizController := controllers.NewQuizController(quizUsecase)
+
+	// Course lessons are completed by reading the article or passing its quiz
+	courseRepository := repositories.NewCourseRepository(db)
+	courseUsecase := usecase.NewCourseUsecase(courseRepository, articleRepository)
+	courseController := controllers.NewCourseController(courseUsecase)
+	readingHistoryUsecase.OnReadingCompleted(courseUsecase.CompleteLessonByReading)
+	quizUsecase.OnQuizPassed(courseUsecase.CompleteLessonByQuiz)
+
+	certificateRepository := repositories.NewCertificateRepository(db)
+	certificateUsecase := usecase.NewCertificateUsecase(certificateRepository, courseRepository, userRepository)
+	certificateController := controllers.NewCertificateController(certificateUsecase)
+	courseUsecase.OnCourseCompleted(certificateUsecase.IssueCertificate)
+
+	// Points are awarded once per verified email, read article, liked article and passed quiz
+	achievementRepository := repositories.NewAchievementRepository(db)
+	achievementUsecase := usecase.NewAchievementUsecase(achievementRepository, userRepository)
+	achievementController := controllers.NewAchievementController(achievementUsecase)
+	userUsecase.OnEmailVerified(achievementUsecase.AwardEmailVerified)
+	readingHistoryUsecase.OnReadingCompleted(achievementUsecase.AwardArticleRead)
+	articleLikedUsecase.OnArticleLiked(achievementUsecase.AwardArticleLiked)
+	quizUsecase.OnQuizPassed(achievementUsecase.AwardQuizPassed)
+
+	// Followers are notified when an article is published directly or by the scheduler
+	notificationRepository := repositories.NewNotificationRepository(db)
+	followUsecase := usecase.NewFollowUsecase(followRepository, notificationRepository, adminRepository, categoryRepository)
+	followController := controllers.NewFollowController(followUsecase)
+	articleUsecase.OnArticlePublished(followUsecase.NotifyFollowers)
+	publishScheduler.OnArticlePublished(followUsecase.NotifyFollowers)
+
+	// Scheduled articles join the related article lists when they are published
+	publishScheduler.OnArticlePublished(articleRecommender.RefreshPublished)
+
+	authorUsecase := usecase.NewAuthorUsecase(adminRepository, followRepository, articleUsecase)
+	authorController := controllers.NewAuthorController(authorUsecase, articleUsecase)
+
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepository)
+	notificationController := controllers.NewNotificationController(notificationUsecase)
+
 	cloudinaryUsecase := usecase.NewMediaUpload()
 	cloudinaryController := controllers.NewCloudinaryController(cloudinaryUsecase)
 
+	healthRepository := repositories.NewHealthRepository(db)
+	healthUsecase := usecase.NewHealthUsecase(healthRepository, publishScheduler)
+	healthController := controllers.NewHealthController(healthUsecase)
+
 	authUsecase := usecase.NewAuthUsecase(adminRepository, userRepository)
 	authControllers := controllers.NewAuthControllers(authUsecase)
 
@@ -57,6 +149,9 @@ func NewRoute(e *echo.Echo, db *gorm.DB) {
 	api := e.Group("/api/v1")
 	public := api.Group("/public")
 
+	// Health check
+	api.GET("/health", healthController.GetHealth)
+
 	// cloudinary
 	public.POST("/cloudinary/file-upload", cloudinaryController.FileUpload)
 	public.POST("/cloudinary/url-upload", cloudinaryController.UrlUpload)
@@ -76,10 +171,62 @@ func NewRoute(e *echo.Echo, db *gorm.DB) {
 	// Forgot Password for All Actor
 	api.POST("/forgot-password", authControllers.ForgotPasswordControllers)
 
-	article := api.Group("/article")
+	// Public article routes add liked_by_me when a user token is sent
+	article := api.Group("/article", m.OptionalToken)
 	article.GET("", articleController.GetAllArticles)
+	article.GET("/trending", articleController.GetTrendingArticles)
+	article.GET("/popular", articleController.GetPopularArticles)
 	article.GET("/:id", articleController.GetArticleById)
-	article.GET("/like/:id", articleLikedController.CreateArticleLikedController)
+	article.GET("/slug/:slug", articleController.GetArticleBySlug)
+	article.GET("/:id/related", articleController.GetRelatedArticles)
+
+	// Article Likes are public, Bookmarks are private to the user
+	article.PUT("/:id/like", articleLikedController.LikeArticleController, m.VerifyToken)
+	article.DELETE("/:id/like", articleLikedController.UnlikeArticleController, m.VerifyToken)
+	article.PUT("/:id/bookmark", articleBookmarkController.BookmarkArticleController, m.VerifyToken)
+	article.DELETE("/:id/bookmark", articleBookmarkController.RemoveBookmarkController, m.VerifyToken)
+	article.PUT("/:id/progress", readingHistoryController.SaveReadingProgress, m.VerifyToken)
+
+	// Article Quiz
+	article.GET("/:id/quiz", quizController.GetQuiz)
+	article.POST("/:id/quiz/attempts", quizController.SubmitQuizAttempt, m.VerifyToken)
+
+	// Article Comments
+	article.GET("/:id/comments", commentController.GetArticleComments)
+	article.POST("/:id/comments", commentController.CreateComment, m.VerifyToken)
+	article.PUT("/:id/comments/:comment_id", commentController.UpdateComment, m.VerifyToken)
+	article.DELETE("/:id/comments/:comment_id", commentController.DeleteComment, m.VerifyToken)
+
+	api.GET("/category", categoryController.GetCategories)
+	api.PUT("/category/:slug/follow", followController.FollowCategory, m.VerifyToken)
+	api.DELETE("/category/:slug/follow", followController.UnfollowCategory, m.VerifyToken)
+
+	// Public author profile adds followed_by_me and liked_by_me when a user token is sent
+	author := api.Group("/author", m.OptionalToken)
+	author.GET("/:username", authorController.GetAuthor)
+	author.PUT("/:username/follow", followController.FollowAuthor, m.VerifyToken)
+	author.DELETE("/:username/follow", followController.UnfollowAuthor, m.VerifyToken)
+
+	// Syndication feeds, filter with ?category= or ?tag=
+	api.GET("/feed.rss", feedController.GetRSSFeed)
+	api.GET("/feed.atom", feedController.GetAtomFeed)
+	api.GET("/sitemap.xml", sitemapController.GetSitemap)
+
+	api.GET("/collection/shared/:token", collectionController.GetSharedCollection, m.OptionalToken)
+
+	// Courses add the progress of enrolled users when a user token is sent
+	course := api.Group("/course", m.OptionalToken)
+	course.GET("", courseController.GetCourses)
+	course.GET("/:id", courseController.GetCourse)
+	course.PUT("/:id/enroll", courseController.EnrollCourse, m.VerifyToken)
+	course.DELETE("/:id/enroll", courseController.UnenrollCourse, m.VerifyToken)
+
+	api.GET("/certificate/verify/:code", certificateController.VerifyCertificate)
+	api.GET("/leaderboard/weekly", achievementController.GetWeeklyLeaderboard, m.OptionalToken)
+
+	tag := api.Group("/tag", m.OptionalToken)
+	tag.GET("/cloud", tagController.GetTagCloud)
+	tag.GET("/:slug/articles", tagController.GetArticlesByTag)
 
 	// User Only
 	user := api.Group("/user")
@@ -90,9 +237,42 @@ func NewRoute(e *echo.Echo, db *gorm.DB) {
 	user.DELETE("", userController.DeleteUserController)
 	user.POST("/change-password", userController.ChangePasswordController)
 	user.GET("/logout", userController.LogoutUserController)
+	user.GET("/feed", articleController.GetPersonalizedFeed)
 
 	// Like Some Article
 	user.GET("/liked/:id", articleLikedController.GetArticleLikedByUserIdController)
+	user.GET("/bookmarks", articleBookmarkController.GetBookmarksController)
+
+	// Reading History, continue reading first
+	user.GET("/history", readingHistoryController.GetReadingHistory)
+	user.DELETE("/history", readingHistoryController.ClearReadingHistory)
+	user.DELETE("/history/:article_id", readingHistoryController.DeleteReadingHistory)
+
+	// Reading list Collections
+	user.GET("/collections", collectionController.GetCollections)
+	user.POST("/collections", collectionController.CreateCollection)
+	user.GET("/collections/:id", collectionController.GetCollection)
+	user.PUT("/collections/:id", collectionController.UpdateCollection)
+	user.DELETE("/collections/:id", collectionController.DeleteCollection)
+	user.POST("/collections/:id/items", collectionController.AddCollectionItem)
+	user.PUT("/collections/:id/items/order", collectionController.ReorderCollectionItems)
+	user.DELETE("/collections/:id/items/:article_id", collectionController.RemoveCollectionItem)
+	user.PUT("/collections/:id/share", collectionController.ShareCollection)
+	user.DELETE("/collections/:id/share", collectionController.UnshareCollection)
+
+	// User Courses
+	user.GET("/courses", courseController.GetUserCourses)
+	user.GET("/certificates", certificateController.GetCertificates)
+
+	// Points, Badges and Reading Streak
+	user.GET("/achievements", achievementController.GetAchievements)
+	user.PUT("/achievements/settings", achievementController.UpdateAchievementSettings)
+
+	// Following and Notifications
+	user.GET("/following", followController.GetFollowing)
+	user.GET("/notifications", notificationController.GetNotifications)
+	user.PUT("/notifications/read", notificationController.MarkAllNotificationsRead)
+	user.PUT("/notifications/:id/read", notificationController.MarkNotificationRead)
 
 	// Admin Only
 	admin := api.Group("/admin")
@@ -105,7 +285,47 @@ func NewRoute(e *echo.Echo, db *gorm.DB) {
 	admin.GET("/logout", adminController.LogoutAdminController)
 
 	// Article Admin Routes
+	admin.GET("/article", articleController.GetAdminArticles)
+	admin.GET("/article/:id", articleController.GetAdminArticleById)
+	admin.PUT("/article/:id/status", articleController.UpdateArticleStatus)
+	admin.PUT("/article/:id/schedule", articleController.ScheduleArticle)
+	admin.DELETE("/article/:id/schedule", articleController.CancelArticleSchedule)
+	admin.POST("/article/preview", articleController.PreviewArticle)
 	admin.POST("/article", articleController.CreateArticle)
 	admin.PUT("/article/:id", articleController.UpdateArticle)
 	admin.DELETE("/article/:id", articleController.DeleteArticle)
+
+	// Article Revision Admin Routes
+	admin.GET("/article/:id/revisions", articleRevisionController.GetRevisions)
+	admin.GET("/article/:id/revisions/diff", articleRevisionController.DiffRevisions)
+	admin.POST("/article/:id/revisions/:rev/restore", articleRevisionController.RestoreRevision)
+
+	// Article Quiz Admin Routes
+	admin.GET("/article/:id/quiz", quizController.GetAdminQuiz)
+	admin.PUT("/article/:id/quiz", quizController.SaveQuiz)
+	admin.DELETE("/article/:id/quiz", quizController.DeleteQuiz)
+
+	// Admin Courses
+	admin.GET("/courses", courseController.GetAdminCourses)
+	admin.POST("/courses", courseController.CreateCourse)
+	admin.GET("/courses/:id", courseController.GetAdminCourse)
+	admin.PUT("/courses/:id", courseController.UpdateCourse)
+	admin.DELETE("/courses/:id", courseController.DeleteCourse)
+
+	// Admin Badges
+	admin.GET("/badges", achievementController.GetBadges)
+	admin.POST("/badges", achievementController.CreateBadge)
+	admin.PUT("/badges/:id", achievementController.UpdateBadge)
+	admin.DELETE("/badges/:id", achievementController.DeleteBadge)
+
+	// Comment Moderation Admin Routes
+	admin.GET("/comments", commentController.GetModerationQueue)
+	admin.PUT("/comments/:id/:action", commentController.ModerateComment)
+	admin.DELETE("/comments/:id", commentController.AdminDeleteComment)
+
+	// Category Admin Routes
+	admin.GET("/category", categoryController.GetCategories)
+	admin.POST("/category", categoryController.CreateCategory)
+	admin.PUT("/category/:id", categoryController.UpdateCategory)
+	admin.DELETE("/category/:id", categoryController.DeleteCategory)
 }
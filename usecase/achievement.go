@@ -0,0 +1,533 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"time"
+	// Timezones of users must load on hosts without zoneinfo
+	_ "time/tzdata"
+)
+
+type AchievementUsecase interface {
+	GetAchievements(userId uint) (dtos.AchievementResponse, error)
+	UpdateAchievementSettings(userId uint, req dtos.AchievementSettingsRequest) (dtos.AchievementResponse, error)
+	GetWeeklyLeaderboard(userId uint, limit int) (dtos.LeaderboardResponse, error)
+	GetBadges() ([]dtos.BadgeResponse, error)
+	CreateBadge(req dtos.BadgeRequest) (dtos.BadgeResponse, error)
+	UpdateBadge(id uint, req dtos.BadgeRequest) (dtos.BadgeResponse, error)
+	DeleteBadge(id uint) error
+	AwardEmailVerified(userId uint, verifiedAt time.Time) error
+	AwardArticleRead(userId uint, articleId uint, completedAt time.Time) error
+	AwardArticleLiked(userId uint, articleId uint, likedAt time.Time) error
+	AwardQuizPassed(userId uint, articleId uint, score int, passedAt time.Time) error
+}
+
+type achievementUsecase struct {
+	achievementRepository repositories.AchievementRepository
+	userRepository        repositories.UserRepository
+}
+
+func NewAchievementUsecase(achievementRepository repositories.AchievementRepository, userRepository repositories.UserRepository) AchievementUsecase {
+	return &achievementUsecase{achievementRepository, userRepository}
+}
+
+// Statistics of a user that badge rules are checked against
+type achievementStats struct {
+	metrics       map[string]int
+	currentStreak int
+}
+
+// GetAchievements godoc
+// @Summary      Get achievements
+// @Description  Get points, reading streak and badge progress of the logged in user, streak days follow the user timezone
+// @Tags         User - Achievement
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.AchievementStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/achievements [get]
+// @Security     BearerAuth
+func (u *achievementUsecase) GetAchievements(userId uint) (dtos.AchievementResponse, error) {
+	user, err := u.userRepository.GetUserById(userId)
+	if err != nil {
+		return dtos.AchievementResponse{}, errors.New("Failed to get user")
+	}
+	loc := userLocation(user)
+
+	stats, err := u.stats(userId, loc)
+	if err != nil {
+		return dtos.AchievementResponse{}, err
+	}
+
+	// Badges added by an admin are awarded to users who already qualify
+	err = u.awardBadges(userId, stats)
+	if err != nil {
+		return dtos.AchievementResponse{}, err
+	}
+
+	since, until := weekRange(time.Now(), loc)
+	weeklyPoints, err := u.achievementRepository.GetPointsBetween(userId, since, until)
+	if err != nil {
+		return dtos.AchievementResponse{}, errors.New("Failed to get weekly points")
+	}
+
+	badges, err := u.achievementRepository.GetBadges()
+	if err != nil {
+		return dtos.AchievementResponse{}, errors.New("Failed to get badges")
+	}
+
+	userBadges, err := u.achievementRepository.GetUserBadges(userId)
+	if err != nil {
+		return dtos.AchievementResponse{}, errors.New("Failed to get user badges")
+	}
+
+	awardedAt := map[uint]time.Time{}
+	for _, userBadge := range userBadges {
+		awardedAt[userBadge.BadgeID] = userBadge.AwardedAt
+	}
+
+	transactions, err := u.achievementRepository.GetPointTransactions(userId, 10)
+	if err != nil {
+		return dtos.AchievementResponse{}, errors.New("Failed to get points")
+	}
+
+	achievement := dtos.AchievementResponse{
+		Points:            stats.metrics[models.BadgeMetricPoints],
+		WeeklyPoints:      weeklyPoints,
+		ArticlesRead:      stats.metrics[models.BadgeMetricArticlesRead],
+		ArticlesLiked:     stats.metrics[models.BadgeMetricArticlesLiked],
+		QuizzesPassed:     stats.metrics[models.BadgeMetricQuizzesPassed],
+		CurrentStreak:     stats.currentStreak,
+		LongestStreak:     stats.metrics[models.BadgeMetricReadingStreak],
+		Timezone:          loc.String(),
+		LeaderboardOptOut: user.LeaderboardOptOut,
+		Badges:            []dtos.BadgeResponse{},
+		RecentPoints:      []dtos.PointTransactionResponse{},
+	}
+
+	for _, badge := range badges {
+		badgeResponse := newBadgeResponse(badge)
+
+		progress := stats.metrics[badge.Metric]
+		if progress > badge.Threshold {
+			progress = badge.Threshold
+		}
+		badgeResponse.Progress = &progress
+
+		at, earned := awardedAt[badge.ID]
+		badgeResponse.Earned = &earned
+		if earned {
+			badgeResponse.AwardedAt = &at
+		}
+
+		achievement.Badges = append(achievement.Badges, badgeResponse)
+	}
+
+	for _, transaction := range transactions {
+		achievement.RecentPoints = append(achievement.RecentPoints, dtos.PointTransactionResponse{
+			Source:    transaction.Source,
+			SourceID:  transaction.SourceID,
+			Points:    transaction.Points,
+			CreatedAt: transaction.CreatedAt,
+		})
+	}
+
+	return achievement, nil
+}
+
+// UpdateAchievementSettings godoc
+// @Summary      Update achievement settings
+// @Description  Set the timezone used for reading streaks and hide the logged in user from the leaderboard
+// @Tags         User - Achievement
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.AchievementSettingsRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.AchievementStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/achievements/settings [put]
+// @Security     BearerAuth
+func (u *achievementUsecase) UpdateAchievementSettings(userId uint, req dtos.AchievementSettingsRequest) (dtos.AchievementResponse, error) {
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil || req.Timezone == "Local" {
+		return dtos.AchievementResponse{}, errors.New("Unknown timezone " + req.Timezone)
+	}
+
+	err = u.achievementRepository.UpdateAchievementSettings(userId, loc.String(), req.LeaderboardOptOut)
+	if err != nil {
+		return dtos.AchievementResponse{}, errors.New("Failed to update achievement settings")
+	}
+
+	return u.GetAchievements(userId)
+}
+
+// GetWeeklyLeaderboard godoc
+// @Summary      Get weekly leaderboard
+// @Description  Get users with the most points this week, users who opted out are hidden. Logged in users also get their own rank
+// @Tags         Leaderboard
+// @Accept       json
+// @Produce      json
+// @Param limit query int false "Number of users, at most 100"
+// @Success      200 {object} dtos.LeaderboardStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /leaderboard/weekly [get]
+func (u *achievementUsecase) GetWeeklyLeaderboard(userId uint, limit int) (dtos.LeaderboardResponse, error) {
+	if limit > 100 {
+		limit = 100
+	}
+
+	// Everyone shares the same week so ranks are comparable
+	loc, err := time.LoadLocation(models.DefaultTimezone)
+	if err != nil {
+		return dtos.LeaderboardResponse{}, errors.New("Failed to load timezone")
+	}
+	since, until := weekRange(time.Now(), loc)
+
+	entries, err := u.achievementRepository.GetLeaderboard(since, until, limit)
+	if err != nil {
+		return dtos.LeaderboardResponse{}, errors.New("Failed to get leaderboard")
+	}
+
+	leaderboard := dtos.LeaderboardResponse{
+		WeekStart: since,
+		WeekEnd:   until,
+		Entries:   []dtos.LeaderboardEntryResponse{},
+	}
+
+	for i, entry := range entries {
+		leaderboard.Entries = append(leaderboard.Entries, dtos.LeaderboardEntryResponse{
+			Rank:         i + 1,
+			Username:     entry.Username,
+			FullName:     entry.FullName,
+			PhotoProfile: entry.PhotoProfile,
+			Points:       entry.Points,
+		})
+	}
+
+	if userId == 0 {
+		return leaderboard, nil
+	}
+
+	leaderboard.MyRank, err = u.achievementRepository.GetLeaderboardRank(userId, since, until)
+	if err != nil {
+		return dtos.LeaderboardResponse{}, errors.New("Failed to get leaderboard rank")
+	}
+	if leaderboard.MyRank > 0 {
+		leaderboard.MyPoints, err = u.achievementRepository.GetPointsBetween(userId, since, until)
+		if err != nil {
+			return dtos.LeaderboardResponse{}, errors.New("Failed to get weekly points")
+		}
+	}
+
+	return leaderboard, nil
+}
+
+// GetBadges godoc
+// @Summary      Get all badges
+// @Description  Get badge rules
+// @Tags         Admin - Badge
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.GetAllBadgeStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/badges [get]
+// @Security BearerAuth
+func (u *achievementUsecase) GetBadges() ([]dtos.BadgeResponse, error) {
+	badges, err := u.achievementRepository.GetBadges()
+	if err != nil {
+		return nil, errors.New("Failed to get badges")
+	}
+
+	var badgeResponses []dtos.BadgeResponse
+	for _, badge := range badges {
+		badgeResponses = append(badgeResponses, newBadgeResponse(badge))
+	}
+
+	return badgeResponses, nil
+}
+
+// CreateBadge godoc
+// @Summary      Create a badge
+// @Description  Create a badge rule, users who already reach the threshold get it when they next earn points or open their achievements
+// @Tags         Admin - Badge
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.BadgeRequest true "Payload Body [RAW]"
+// @Success      201 {object} dtos.BadgeStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/badges [post]
+// @Security BearerAuth
+func (u *achievementUsecase) CreateBadge(req dtos.BadgeRequest) (dtos.BadgeResponse, error) {
+	code := helpers.CreateSlug(req.Code)
+	err := u.checkBadgeCode(code, 0)
+	if err != nil {
+		return dtos.BadgeResponse{}, err
+	}
+
+	badge, err := u.achievementRepository.CreateBadge(models.Badge{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Metric:      req.Metric,
+		Threshold:   req.Threshold,
+	})
+	if err != nil {
+		return dtos.BadgeResponse{}, errors.New("Failed to create badge")
+	}
+
+	return newBadgeResponse(badge), nil
+}
+
+// UpdateBadge godoc
+// @Summary      Update a badge
+// @Description  Update a badge rule, users keep badges they already earned
+// @Tags         Admin - Badge
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID badge"
+// @Param        request body dtos.BadgeRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.BadgeStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/badges/{id} [put]
+// @Security BearerAuth
+func (u *achievementUsecase) UpdateBadge(id uint, req dtos.BadgeRequest) (dtos.BadgeResponse, error) {
+	badge, err := u.achievementRepository.GetBadgeByID(id)
+	if err != nil {
+		return dtos.BadgeResponse{}, errors.New("Badge not found")
+	}
+
+	code := helpers.CreateSlug(req.Code)
+	err = u.checkBadgeCode(code, badge.ID)
+	if err != nil {
+		return dtos.BadgeResponse{}, err
+	}
+
+	badge.Code = code
+	badge.Name = req.Name
+	badge.Description = req.Description
+	badge.Icon = req.Icon
+	badge.Metric = req.Metric
+	badge.Threshold = req.Threshold
+
+	badge, err = u.achievementRepository.UpdateBadge(badge)
+	if err != nil {
+		return dtos.BadgeResponse{}, errors.New("Failed to update badge")
+	}
+
+	return newBadgeResponse(badge), nil
+}
+
+// DeleteBadge godoc
+// @Summary      Delete a badge
+// @Description  Delete a badge rule, the badge is no longer shown to users who earned it
+// @Tags         Admin - Badge
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID badge"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/badges/{id} [delete]
+// @Security BearerAuth
+func (u *achievementUsecase) DeleteBadge(id uint) error {
+	badge, err := u.achievementRepository.GetBadgeByID(id)
+	if err != nil {
+		return errors.New("Badge not found")
+	}
+
+	err = u.achievementRepository.DeleteBadge(badge)
+	if err != nil {
+		return errors.New("Failed to delete badge")
+	}
+
+	return nil
+}
+
+// Email verification handler
+func (u *achievementUsecase) AwardEmailVerified(userId uint, verifiedAt time.Time) error {
+	return u.award(userId, models.PointSourceVerifyEmail, userId, verifiedAt)
+}
+
+// Reading completion handler
+func (u *achievementUsecase) AwardArticleRead(userId uint, articleId uint, completedAt time.Time) error {
+	return u.award(userId, models.PointSourceReadArticle, articleId, completedAt)
+}
+
+// Like handler, liking the same article again gives no points
+func (u *achievementUsecase) AwardArticleLiked(userId uint, articleId uint, likedAt time.Time) error {
+	return u.award(userId, models.PointSourceLikeArticle, articleId, likedAt)
+}
+
+// Quiz pass handler
+func (u *achievementUsecase) AwardQuizPassed(userId uint, articleId uint, score int, passedAt time.Time) error {
+	return u.award(userId, models.PointSourcePassQuiz, articleId, passedAt)
+}
+
+// Add the points of a source once and award badges reached with them
+func (u *achievementUsecase) award(userId uint, source string, sourceId uint, at time.Time) error {
+	created, err := u.achievementRepository.CreatePointTransaction(models.PointTransaction{
+		CreatedAt: at,
+		UserID:    userId,
+		Source:    source,
+		SourceID:  sourceId,
+		Points:    models.PointValues[source],
+	})
+	if err != nil {
+		return errors.New("Failed to add points")
+	}
+	if !created {
+		return nil
+	}
+
+	user, err := u.userRepository.GetUserById(userId)
+	if err != nil {
+		return errors.New("Failed to get user")
+	}
+
+	stats, err := u.stats(userId, userLocation(user))
+	if err != nil {
+		return err
+	}
+
+	return u.awardBadges(userId, stats)
+}
+
+func (u *achievementUsecase) stats(userId uint, loc *time.Location) (achievementStats, error) {
+	summaries, err := u.achievementRepository.GetPointSummaries(userId)
+	if err != nil {
+		return achievementStats{}, errors.New("Failed to get points")
+	}
+
+	stats := achievementStats{metrics: map[string]int{}}
+	for _, summary := range summaries {
+		stats.metrics[models.BadgeMetricPoints] += summary.Points
+
+		switch summary.Source {
+		case models.PointSourceReadArticle:
+			stats.metrics[models.BadgeMetricArticlesRead] = summary.Count
+		case models.PointSourceLikeArticle:
+			stats.metrics[models.BadgeMetricArticlesLiked] = summary.Count
+		case models.PointSourcePassQuiz:
+			stats.metrics[models.BadgeMetricQuizzesPassed] = summary.Count
+		}
+	}
+
+	// Every day with reading progress counts, not only days an article was completed for the first time
+	readAt, err := u.achievementRepository.GetReadingTimes(userId)
+	if err != nil {
+		return achievementStats{}, errors.New("Failed to get reading days")
+	}
+
+	// Badges use the longest streak so a broken streak does not take a badge back
+	stats.currentStreak, stats.metrics[models.BadgeMetricReadingStreak] = helpers.DailyStreak(readAt, loc, time.Now())
+
+	return stats, nil
+}
+
+func (u *achievementUsecase) awardBadges(userId uint, stats achievementStats) error {
+	badges, err := u.achievementRepository.GetBadges()
+	if err != nil {
+		return errors.New("Failed to get badges")
+	}
+
+	var userBadges []models.UserBadge
+	for _, badge := range badges {
+		if stats.metrics[badge.Metric] >= badge.Threshold {
+			userBadges = append(userBadges, models.UserBadge{
+				UserID:    userId,
+				BadgeID:   badge.ID,
+				AwardedAt: time.Now(),
+			})
+		}
+	}
+
+	err = u.achievementRepository.CreateUserBadges(userBadges)
+	if err != nil {
+		return errors.New("Failed to award badges")
+	}
+
+	return nil
+}
+
+func (u *achievementUsecase) checkBadgeCode(code string, badgeID uint) error {
+	if code == "" {
+		return errors.New("Badge code must contain letters or numbers")
+	}
+
+	taken, err := u.achievementRepository.IsBadgeCodeTaken(code, badgeID)
+	if err != nil {
+		return errors.New("Failed to check badge code")
+	}
+	if taken {
+		return errors.New("Badge code " + code + " is already used")
+	}
+
+	return nil
+}
+
+// Timezone chosen by the user, the default timezone when empty or unknown
+func userLocation(user models.User) *time.Location {
+	if user.Timezone != "" {
+		loc, err := time.LoadLocation(user.Timezone)
+		if err == nil {
+			return loc
+		}
+	}
+
+	loc, err := time.LoadLocation(models.DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Week from Monday midnight to the next Monday midnight in the location
+func weekRange(now time.Time, loc *time.Location) (time.Time, time.Time) {
+	now = now.In(loc)
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+
+	since := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, loc)
+
+	return since, since.AddDate(0, 0, 7)
+}
+
+func newBadgeResponse(badge models.Badge) dtos.BadgeResponse {
+	return dtos.BadgeResponse{
+		BadgeID:     badge.ID,
+		Code:        badge.Code,
+		Name:        badge.Name,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+		Metric:      badge.Metric,
+		Threshold:   badge.Threshold,
+	}
+}
@@ -412,11 +412,13 @@ func (u *adminUsecase) GetAdminById(id uint) (res dtos.AdminProfileResponse, err
 	}
 
 	res = dtos.AdminProfileResponse{
-		ID:       admin.ID,
-		Username: admin.Username,
-		Nama:     admin.Nama,
-		Email:    admin.Email,
-		Role:     admin.Role,
+		ID:          admin.ID,
+		Username:    admin.Username,
+		Nama:        admin.Nama,
+		Email:       admin.Email,
+		Role:        admin.Role,
+		Bio:         admin.Bio,
+		SocialLinks: newSocialLinksResponse(admin),
 	}
 
 	return res, nil
@@ -452,6 +454,7 @@ func (u *adminUsecase) UpdateAdmin(id uint, req dtos.UpdateAdminRequest) (dtos.U
 	admins.Email = req.Email
 	admins.Role = req.Role
 	admins.Username = req.Username
+	updateAuthorProfile(&admins, req)
 
 	// Check Role and save role information from JWT Cookie
 	admin, err := u.adminRepository.ReadToken(id)
@@ -486,10 +489,40 @@ func (u *adminUsecase) UpdateAdmin(id uint, req dtos.UpdateAdminRequest) (dtos.U
 	res.Nama = admins.Nama
 	res.Email = admins.Email
 	res.Role = admins.Role
+	res.Bio = admins.Bio
+	res.SocialLinks = newSocialLinksResponse(admins)
 
 	return res, nil
 }
 
+// Set author profile fields that are sent in the request
+func updateAuthorProfile(admin *models.Administrator, req dtos.UpdateAdminRequest) {
+	if req.Bio != nil {
+		admin.Bio = *req.Bio
+	}
+	if req.Website != nil {
+		admin.Website = *req.Website
+	}
+	if req.Twitter != nil {
+		admin.Twitter = *req.Twitter
+	}
+	if req.Instagram != nil {
+		admin.Instagram = *req.Instagram
+	}
+	if req.LinkedIn != nil {
+		admin.LinkedIn = *req.LinkedIn
+	}
+}
+
+func newSocialLinksResponse(admin models.Administrator) dtos.SocialLinksResponse {
+	return dtos.SocialLinksResponse{
+		Website:   admin.Website,
+		Twitter:   admin.Twitter,
+		Instagram: admin.Instagram,
+		LinkedIn:  admin.LinkedIn,
+	}
+}
+
 // DeleteAdmin godoc
 // @Summary      Delete an Admin
 // @Description  Delete an Admin
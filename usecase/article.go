@@ -6,25 +6,89 @@ import (
 	"go_bedu/helpers"
 	"go_bedu/models"
 	"go_bedu/repositories"
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Called when an article becomes published, also when it is published again after archiving
+type ArticlePublishedHandler func(article models.Article) error
+
 type ArticleUsecase interface {
-	GetAllArticles(page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	GetAllArticles(filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	SearchArticles(query string, filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	GetTrendingArticles(page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	GetPopularArticles(period string, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	GetPersonalizedFeed(userId uint, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
 	GetArticleByID(id uint) (dtos.ArticleDetailResponse, error)
+	GetArticlesByIDs(ids []uint) ([]dtos.ArticleDetailResponse, error)
+	GetArticleBySlug(slug string) (dtos.ArticleDetailResponse, string, error)
+	GetRelatedArticles(id uint, limit int) ([]dtos.ArticleDetailResponse, error)
+	GetAdminArticles(filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	GetAdminArticleByID(id uint) (dtos.ArticleDetailResponse, error)
+	UpdateArticleStatus(id uint, req dtos.ArticleStatusRequest) (dtos.ArticleDetailResponse, error)
+	ScheduleArticle(id uint, req dtos.ArticleScheduleRequest) (dtos.ArticleDetailResponse, error)
+	CancelArticleSchedule(id uint) (dtos.ArticleDetailResponse, error)
+	PreviewArticle(req dtos.ArticlePreviewRequest) dtos.ArticlePreviewResponse
+	MarkLikedByUser(userId uint, articleResponses []dtos.ArticleDetailResponse) error
 	GetArticleByImage(image string) (int64, error)
 	GetArticleByThumbnail(thumbnail string) (int64, error)
 	CreateArticle(article *dtos.CreateArticlesRequest) (dtos.ArticleDetailResponse, error)
 	UpdateArticle(id uint, article dtos.UpdateArticlesRequest) (dtos.ArticleDetailResponse, error)
 	DeleteArticle(id uint) error
+	OnArticlePublished(handler ArticlePublishedHandler)
 }
 
 type articleUsecase struct {
-	articleRepository repositories.ArticleRepository
+	articleRepository  repositories.ArticleRepository
+	categoryRepository repositories.CategoryRepository
+	tagRepository      repositories.TagRepository
+	commentRepository  repositories.CommentRepository
+	revisionRepository repositories.ArticleRevisionRepository
+	rankingRepository  repositories.ArticleRankingRepository
+	likedRepository    repositories.ArticleLikedRepository
+	followRepository   repositories.FollowRepository
+	recommender        ArticleRecommender
+	publishedHandlers  []ArticlePublishedHandler
+}
+
+// Allowed status changes of the publishing workflow
+var articleTransitions = map[string][]string{
+	models.ArticleDraft:     {models.ArticleInReview, models.ArticlePublished, models.ArticleArchived},
+	models.ArticleInReview:  {models.ArticleDraft, models.ArticlePublished},
+	models.ArticlePublished: {models.ArticleDraft, models.ArticleArchived},
+	models.ArticleArchived:  {models.ArticleDraft, models.ArticlePublished},
 }
 
-func NewArticleUsecase(ArticleRepository repositories.ArticleRepository) ArticleUsecase {
-	return &articleUsecase{ArticleRepository}
+func NewArticleUsecase(ArticleRepository repositories.ArticleRepository, CategoryRepository repositories.CategoryRepository, TagRepository repositories.TagRepository, CommentRepository repositories.CommentRepository, RevisionRepository repositories.ArticleRevisionRepository, RankingRepository repositories.ArticleRankingRepository, LikedRepository repositories.ArticleLikedRepository, FollowRepository repositories.FollowRepository, Recommender ArticleRecommender) ArticleUsecase {
+	return &articleUsecase{
+		articleRepository:  ArticleRepository,
+		categoryRepository: CategoryRepository,
+		tagRepository:      TagRepository,
+		commentRepository:  CommentRepository,
+		revisionRepository: RevisionRepository,
+		rankingRepository:  RankingRepository,
+		likedRepository:    LikedRepository,
+		followRepository:   FollowRepository,
+		recommender:        Recommender,
+	}
+}
+
+// Register a handler for published articles, handlers are registered while wiring routes
+func (u *articleUsecase) OnArticlePublished(handler ArticlePublishedHandler) {
+	u.publishedHandlers = append(u.publishedHandlers, handler)
+}
+
+// Article is already saved, a failing handler is logged
+func (u *articleUsecase) notifyArticlePublished(article models.Article) {
+	for _, handler := range u.publishedHandlers {
+		err := handler(article)
+		if err != nil {
+			log.Printf("article: published handler: article %d: %v", article.ID, err)
+		}
+	}
 }
 
 // GetAllArticles godoc
@@ -35,6 +99,9 @@ func NewArticleUsecase(ArticleRepository repositories.ArticleRepository) Article
 // @Produce      json
 // @Param page query int false "Page number"
 // @Param limit query int false "Number of items per page"
+// @Param q query string false "Search keyword, results are ranked by relevance"
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag slug"
 // @Success      200 {object} dtos.GetAllArticleStatusOKResponse
 // @Failure      400 {object} dtos.BadRequestResponse
 // @Failure      401 {object} dtos.UnauthorizedResponse
@@ -42,31 +109,202 @@ func NewArticleUsecase(ArticleRepository repositories.ArticleRepository) Article
 // @Failure      404 {object} dtos.NotFoundResponse
 // @Failure      500 {object} dtos.InternalServerErrorResponse
 // @Router       /article [get]
-func (u *articleUsecase) GetAllArticles(page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
-	articles, count, err := u.articleRepository.GetAllArticles(page, limit)
+func (u *articleUsecase) GetAllArticles(filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	// Public listing only returns published articles
+	filter.Status = models.ArticlePublished
+
+	return u.getArticles(filter, page, limit)
+}
+
+// GetAdminArticles godoc
+// @Summary      Get all articles for admin
+// @Description  Get articles of every status, filter by status to list drafts or articles in review
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Param status query string false "draft, in_review, published or archived"
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag slug"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article [get]
+// @Security BearerAuth
+func (u *articleUsecase) GetAdminArticles(filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	if filter.Status != "" && articleTransitions[filter.Status] == nil {
+		return nil, 0, errors.New("Article status is not valid")
+	}
+
+	return u.getArticles(filter, page, limit)
+}
+
+func (u *articleUsecase) getArticles(filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	articleFilter, err := u.articleFilter(filter)
+	if err != nil {
+		// Unknown category or tag has no article
+		return nil, 0, nil
+	}
+
+	articles, count, err := u.articleRepository.GetAllArticles(articleFilter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var articleResponses []dtos.ArticleDetailResponse
 	for _, article := range articles {
-		articleResponses = append(articleResponses, dtos.ArticleDetailResponse{
-			ArticleID:   article.ID,
-			Title:       article.Title,
-			Abstract:    article.Abstract,
-			Thumbnail:   article.Thumbnail,
-			Image:       article.Image,
-			Description: article.Description,
-			Label:       article.Label,
-			Slug:        article.Slug,
-			CreatedAt:   article.CreatedAt,
-			UpdatedAt:   article.UpdatedAt,
-		})
+		articleResponses = append(articleResponses, newArticleResponse(article))
+	}
+
+	err = u.attachArticleCounts(articleResponses)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	return articleResponses, count, nil
 }
 
+// Search articles by keyword, ranked by relevance with highlighted snippets
+func (u *articleUsecase) SearchArticles(query string, filter dtos.ArticleFilter, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	filter.Status = models.ArticlePublished
+
+	articleFilter, err := u.articleFilter(filter)
+	if err != nil {
+		return nil, 0, nil
+	}
+
+	results, count, err := u.articleRepository.SearchArticles(query, articleFilter, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to search articles")
+	}
+
+	terms := helpers.SearchTerms(query)
+
+	var articleResponses []dtos.ArticleDetailResponse
+	for _, result := range results {
+		articleResponse := newArticleResponse(result.Article)
+		articleResponse.Relevance = result.Relevance
+		articleResponse.Highlight = &dtos.ArticleHighlight{
+			Title:       helpers.HighlightSnippet(result.Title, terms, 80),
+			Abstract:    helpers.HighlightSnippet(result.Abstract, terms, 80),
+			Description: helpers.HighlightSnippet(result.Description, terms, 80),
+			Label:       helpers.HighlightSnippet(result.Label, terms, 80),
+		}
+		articleResponses = append(articleResponses, articleResponse)
+	}
+
+	err = u.attachArticleCounts(articleResponses)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articleResponses, count, nil
+}
+
+// GetTrendingArticles godoc
+// @Summary      Get trending articles
+// @Description  Get articles ranked by recent likes and views, recent activity weighs more. Rankings are refreshed periodically
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/trending [get]
+func (u *articleUsecase) GetTrendingArticles(page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	return u.getRankedArticles(models.RankingTrending, page, limit)
+}
+
+// GetPopularArticles godoc
+// @Summary      Get most liked articles
+// @Description  Get articles ranked by likes in the period. Rankings are refreshed periodically
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param period query string false "week, month or all, default week"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/popular [get]
+func (u *articleUsecase) GetPopularArticles(period string, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	kinds := map[string]string{
+		"":      models.RankingPopularWeek,
+		"week":  models.RankingPopularWeek,
+		"month": models.RankingPopularMonth,
+		"all":   models.RankingPopularAll,
+	}
+
+	kind, ok := kinds[period]
+	if !ok {
+		return nil, 0, errors.New("Period must be week, month or all")
+	}
+
+	return u.getRankedArticles(kind, page, limit)
+}
+
+func (u *articleUsecase) getRankedArticles(kind string, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	rankings, count, err := u.rankingRepository.GetRankings(kind, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get article ranking")
+	}
+
+	var articleResponses []dtos.ArticleDetailResponse
+	for _, ranking := range rankings {
+		articleResponse := newArticleResponse(ranking.Article)
+		articleResponse.Position = ranking.Position
+		articleResponse.Score = ranking.Score
+		articleResponses = append(articleResponses, articleResponse)
+	}
+
+	err = u.attachArticleCounts(articleResponses)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articleResponses, count, nil
+}
+
+// Get published articles keeping the order of the given IDs, missing or unpublished articles are skipped
+func (u *articleUsecase) GetArticlesByIDs(ids []uint) ([]dtos.ArticleDetailResponse, error) {
+	articles, err := u.articleRepository.GetPublishedArticlesByIDs(ids)
+	if err != nil {
+		return nil, errors.New("Failed to get articles")
+	}
+
+	articleByID := map[uint]models.Article{}
+	for _, article := range articles {
+		articleByID[article.ID] = article
+	}
+
+	articleResponses := []dtos.ArticleDetailResponse{}
+	for _, id := range ids {
+		if article, ok := articleByID[id]; ok {
+			articleResponses = append(articleResponses, newArticleResponse(article))
+		}
+	}
+
+	err = u.attachArticleCounts(articleResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	return articleResponses, nil
+}
+
 // GetArticleByID godoc
 // @Summary      Get article by ID
 // @Description  Get article by ID
@@ -84,25 +322,261 @@ func (u *articleUsecase) GetAllArticles(page, limit int) ([]dtos.ArticleDetailRe
 func (u *articleUsecase) GetArticleByID(id uint) (dtos.ArticleDetailResponse, error) {
 	var articleResponses dtos.ArticleDetailResponse
 
+	article, err := u.articleRepository.GetArticleByID(id)
+	if err != nil || article.Status != models.ArticlePublished {
+		return articleResponses, errors.New("Failed to get article")
+	}
+
+	return u.articleDetail(article)
+}
+
+// GetArticleBySlug godoc
+// @Summary      Get article by slug
+// @Description  Get published article by slug, previous slugs redirect to the current slug with 301
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param slug path string true "Slug article"
+// @Success      200 {object} dtos.ArticleStatusOKResponse
+// @Success      301
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/slug/{slug} [get]
+func (u *articleUsecase) GetArticleBySlug(slug string) (dtos.ArticleDetailResponse, string, error) {
+	var articleResponses dtos.ArticleDetailResponse
+
+	article, err := u.articleRepository.GetArticleBySlug(slug)
+	if err == nil {
+		if article.Status != models.ArticlePublished {
+			return articleResponses, "", errors.New("Failed to get article")
+		}
+
+		articleResponses, err = u.articleDetail(article)
+		return articleResponses, "", err
+	}
+
+	// Old slug after a title change, the caller redirects to the current slug
+	articleSlug, err := u.articleRepository.GetArticleSlugHistory(slug)
+	if err != nil {
+		return articleResponses, "", errors.New("Failed to get article")
+	}
+
+	article, err = u.articleRepository.GetArticleByID(articleSlug.ArticleID)
+	if err != nil || article.Status != models.ArticlePublished {
+		return articleResponses, "", errors.New("Failed to get article")
+	}
+
+	return articleResponses, article.Slug, nil
+}
+
+// GetRelatedArticles godoc
+// @Summary      Get related articles
+// @Description  Get published articles similar to the article by category, tags and content
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param limit query int false "Number of related articles, default 5"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/related [get]
+func (u *articleUsecase) GetRelatedArticles(id uint, limit int) ([]dtos.ArticleDetailResponse, error) {
+	article, err := u.articleRepository.GetArticleByID(id)
+	if err != nil || article.Status != models.ArticlePublished {
+		return nil, errors.New("Failed to get article")
+	}
+
+	relations, err := u.recommender.GetRelated(article.ID, limit)
+	if err != nil {
+		return nil, errors.New("Failed to get related articles")
+	}
+
+	articleResponses := []dtos.ArticleDetailResponse{}
+	for _, relation := range relations {
+		articleResponse := newArticleResponse(relation.Related)
+		articleResponse.Score = relation.Score
+		articleResponses = append(articleResponses, articleResponse)
+	}
+
+	err = u.attachArticleCounts(articleResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	return articleResponses, nil
+}
+
+// GetAdminArticleByID godoc
+// @Summary      Get article by ID for admin
+// @Description  Get article of any status by ID
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.ArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id} [get]
+// @Security BearerAuth
+func (u *articleUsecase) GetAdminArticleByID(id uint) (dtos.ArticleDetailResponse, error) {
+	var articleResponses dtos.ArticleDetailResponse
+
 	article, err := u.articleRepository.GetArticleByID(id)
 	if err != nil {
 		return articleResponses, errors.New("Failed to get article")
 	}
 
-	articleResponse := dtos.ArticleDetailResponse{
-		ArticleID:   article.ID,
-		Thumbnail:   article.Thumbnail,
-		Title:       article.Title,
-		Image:       article.Image,
-		Abstract:    article.Abstract,
-		Description: article.Description,
-		Label:       article.Label,
-		Slug:        article.Slug,
-		CreatedAt:   article.CreatedAt,
-		UpdatedAt:   article.UpdatedAt,
+	return u.articleDetail(article)
+}
+
+// UpdateArticleStatus godoc
+// @Summary      Change article status
+// @Description  Move article through the draft, in_review, published and archived workflow
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param        request body dtos.ArticleStatusRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.ArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/status [put]
+// @Security BearerAuth
+func (u *articleUsecase) UpdateArticleStatus(id uint, req dtos.ArticleStatusRequest) (dtos.ArticleDetailResponse, error) {
+	var articleResponse dtos.ArticleDetailResponse
+
+	article, err := u.articleRepository.GetArticleByID(id)
+	if err != nil {
+		return articleResponse, errors.New("Failed to get article")
+	}
+
+	if !canTransitionArticle(article.Status, req.Status) {
+		return articleResponse, errors.New("Cannot change article status from " + article.Status + " to " + req.Status)
+	}
+
+	wasPublished := article.Status == models.ArticlePublished
+	setArticleStatus(&article, req.Status)
+
+	article, err = u.articleRepository.UpdateArticle(article)
+	if err != nil {
+		return articleResponse, errors.New("Failed to update article status")
+	}
+
+	// Only published articles are recommended, entering or leaving published changes the related lists
+	if wasPublished || article.Status == models.ArticlePublished {
+		u.recommender.QueueRefresh(article.ID)
+	}
+
+	if article.Status == models.ArticlePublished {
+		u.notifyArticlePublished(article)
 	}
 
-	return articleResponse, nil
+	return u.articleDetail(article)
+}
+
+// ScheduleArticle godoc
+// @Summary      Schedule or reschedule article publishing
+// @Description  Queue a draft or in review article to be published at a future time
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param        request body dtos.ArticleScheduleRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.ArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/schedule [put]
+// @Security BearerAuth
+func (u *articleUsecase) ScheduleArticle(id uint, req dtos.ArticleScheduleRequest) (dtos.ArticleDetailResponse, error) {
+	var articleResponse dtos.ArticleDetailResponse
+
+	article, err := u.articleRepository.GetArticleByID(id)
+	if err != nil {
+		return articleResponse, errors.New("Failed to get article")
+	}
+
+	if article.Status != models.ArticleDraft && article.Status != models.ArticleInReview {
+		return articleResponse, errors.New("Only draft or in review article can be scheduled")
+	}
+
+	if !req.ScheduledAt.After(time.Now()) {
+		return articleResponse, errors.New("Schedule time must be in the future")
+	}
+
+	article.ScheduledAt = &req.ScheduledAt
+
+	article, err = u.articleRepository.UpdateArticle(article)
+	if err != nil {
+		return articleResponse, errors.New("Failed to schedule article")
+	}
+
+	return u.articleDetail(article)
+}
+
+// CancelArticleSchedule godoc
+// @Summary      Cancel article schedule
+// @Description  Cancel scheduled publishing, the article keeps its current status
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.ArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/schedule [delete]
+// @Security BearerAuth
+func (u *articleUsecase) CancelArticleSchedule(id uint) (dtos.ArticleDetailResponse, error) {
+	var articleResponse dtos.ArticleDetailResponse
+
+	article, err := u.articleRepository.GetArticleByID(id)
+	if err != nil {
+		return articleResponse, errors.New("Failed to get article")
+	}
+
+	if article.ScheduledAt == nil {
+		return articleResponse, errors.New("Article is not scheduled")
+	}
+
+	article.ScheduledAt = nil
+
+	article, err = u.articleRepository.UpdateArticle(article)
+	if err != nil {
+		return articleResponse, errors.New("Failed to cancel article schedule")
+	}
+
+	return u.articleDetail(article)
+}
+
+// Build single article response with its counters
+func (u *articleUsecase) articleDetail(article models.Article) (dtos.ArticleDetailResponse, error) {
+	var articleResponses dtos.ArticleDetailResponse
+
+	articleResponse := []dtos.ArticleDetailResponse{newArticleResponse(article)}
+	err := u.attachArticleCounts(articleResponse)
+	if err != nil {
+		return articleResponses, err
+	}
+
+	return articleResponse[0], nil
 }
 
 // CreateArticle godoc
@@ -123,17 +597,50 @@ func (u *articleUsecase) GetArticleByID(id uint) (dtos.ArticleDetailResponse, er
 func (u *articleUsecase) CreateArticle(article *dtos.CreateArticlesRequest) (dtos.ArticleDetailResponse, error) {
 	var articleResponses dtos.ArticleDetailResponse
 
-	slug := helpers.CreateSlug(article.Title)
+	category, err := u.resolveCategory(article.CategoryID, article.Label)
+	if err != nil {
+		return articleResponses, err
+	}
+
+	slug, err := u.uniqueSlug(article.Title, 0)
+	if err != nil {
+		return articleResponses, err
+	}
+
+	wordCount, readingTime := helpers.ReadingStats(article.Description)
+	descriptionHTML, toc := helpers.RenderMarkdown(article.Description)
 
 	CreateArticle := models.Article{
 		AdministratorID: article.AdministratorID,
+		CategoryID:      &category.ID,
+		Category:        &category,
 		Thumbnail:       article.Thumbnail,
 		Title:           article.Title,
 		Description:     article.Description,
+		DescriptionHTML: descriptionHTML,
+		TableOfContents: helpers.EncodeTOC(toc),
+		WordCount:       wordCount,
+		ReadingTime:     readingTime,
 		Image:           article.Image,
-		Label:           article.Label,
+		Label:           category.Name,
 		Slug:            slug,
 		Abstract:        article.Abstract,
+		MetaTitle:       article.MetaTitle,
+		MetaDescription: article.MetaDescription,
+		CanonicalURL:    article.CanonicalURL,
+		OGImage:         article.OGImage,
+	}
+
+	// New article is a draft unless the admin sends another status
+	status := article.Status
+	if status == "" {
+		status = models.ArticleDraft
+	}
+	setArticleStatus(&CreateArticle, status)
+
+	tags, err := u.resolveTags(article.Tags)
+	if err != nil {
+		return articleResponses, err
 	}
 
 	createdArticle, err := u.articleRepository.CreateArticle(CreateArticle)
@@ -141,20 +648,24 @@ func (u *articleUsecase) CreateArticle(article *dtos.CreateArticlesRequest) (dto
 		return articleResponses, errors.New("Failed to create article")
 	}
 
-	articleResponse := dtos.ArticleDetailResponse{
-		ArticleID:   createdArticle.ID,
-		Thumbnail:   createdArticle.Thumbnail,
-		Title:       createdArticle.Title,
-		Abstract:    createdArticle.Abstract,
-		Image:       createdArticle.Image,
-		Description: createdArticle.Description,
-		Label:       createdArticle.Label,
-		Slug:        createdArticle.Slug,
-		CreatedAt:   createdArticle.CreatedAt,
-		UpdatedAt:   createdArticle.UpdatedAt,
+	err = u.articleRepository.ReplaceArticleTags(createdArticle, tags)
+	if err != nil {
+		return articleResponses, errors.New("Failed to save article tags")
+	}
+	createdArticle.Tags = tags
+
+	err = u.saveRevision(createdArticle, createdArticle.AdministratorID)
+	if err != nil {
+		return articleResponses, err
+	}
+
+	u.recommender.QueueRefresh(createdArticle.ID)
+
+	if createdArticle.Status == models.ArticlePublished {
+		u.notifyArticlePublished(createdArticle)
 	}
 
-	return articleResponse, nil
+	return u.articleDetail(createdArticle)
 }
 
 // UpdateArticle godoc
@@ -184,34 +695,117 @@ func (u *articleUsecase) UpdateArticle(id uint, article dtos.UpdateArticlesReque
 		return articleResponse, errors.New("Failed to get article")
 	}
 
-	slug := helpers.CreateSlug(articles.Title)
+	category, err := u.resolveCategory(article.CategoryID, article.Label)
+	if err != nil {
+		return articleResponse, err
+	}
+
+	// Articles created before revision history keep their current content as the first revision
+	total, err := u.revisionRepository.CountRevisions(articles.ID)
+	if err != nil {
+		return articleResponse, errors.New("Failed to get article revisions")
+	}
+	if total == 0 {
+		err = u.saveRevision(articles, articles.AdministratorID)
+		if err != nil {
+			return articleResponse, err
+		}
+	}
+
+	// Slug follows the new title, the old slug is kept for redirect
+	if article.Title != articles.Title {
+		slug, err := u.uniqueSlug(article.Title, articles.ID)
+		if err != nil {
+			return articleResponse, err
+		}
+
+		if slug != articles.Slug && articles.Slug != "" {
+			err = u.articleRepository.SaveArticleSlugHistory(articles.ID, articles.Slug)
+			if err != nil {
+				return articleResponse, errors.New("Failed to save article slug history")
+			}
+		}
+		articles.Slug = slug
+	}
+
+	// Rendered once on save so responses do not render Markdown on every request
+	descriptionHTML, toc := helpers.RenderMarkdown(article.Description)
 
 	articles.Title = article.Title
 	articles.Description = article.Description
+	articles.DescriptionHTML = descriptionHTML
+	articles.TableOfContents = helpers.EncodeTOC(toc)
+	articles.WordCount, articles.ReadingTime = helpers.ReadingStats(article.Description)
 	articles.Image = article.Image
 	articles.Thumbnail = article.Thumbnail
 	articles.Abstract = article.Abstract
-	articles.Label = article.Label
-	articles.Slug = slug
+	articles.CategoryID = &category.ID
+	articles.Category = &category
+	articles.Label = category.Name
+	if article.MetaTitle != nil {
+		articles.MetaTitle = *article.MetaTitle
+	}
+	if article.MetaDescription != nil {
+		articles.MetaDescription = *article.MetaDescription
+	}
+	if article.CanonicalURL != nil {
+		articles.CanonicalURL = *article.CanonicalURL
+	}
+	if article.OGImage != nil {
+		articles.OGImage = *article.OGImage
+	}
 
 	articles, err = u.articleRepository.UpdateArticle(articles)
 	if err != nil {
 		return articleResponse, errors.New("Failed to update article")
 	}
 
-	articleResponse.ArticleID = articles.ID
-	articleResponse.Title = articles.Title
-	articleResponse.Image = articles.Image
-	articleResponse.Thumbnail = articles.Thumbnail
-	articleResponse.Abstract = articles.Abstract
-	articleResponse.Description = articles.Description
-	articleResponse.Label = articles.Label
-	articleResponse.Slug = articles.Slug
-	articleResponse.CreatedAt = articles.CreatedAt
-	articleResponse.UpdatedAt = articles.UpdatedAt
+	// Tags are only replaced when the request sends them
+	if article.Tags != nil {
+		tags, err := u.resolveTags(article.Tags)
+		if err != nil {
+			return articleResponse, err
+		}
+
+		err = u.articleRepository.ReplaceArticleTags(articles, tags)
+		if err != nil {
+			return articleResponse, errors.New("Failed to save article tags")
+		}
+		articles.Tags = tags
+	}
+
+	err = u.saveRevision(articles, article.AdministratorID)
+	if err != nil {
+		return articleResponse, err
+	}
 
-	return articleResponse, nil
+	u.recommender.QueueRefresh(articles.ID)
 
+	return u.articleDetail(articles)
+}
+
+// PreviewArticle godoc
+// @Summary      Preview article description
+// @Description  Render Markdown description into sanitized HTML with table of contents without saving
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.ArticlePreviewRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.ArticlePreviewStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/preview [post]
+// @Security BearerAuth
+func (u *articleUsecase) PreviewArticle(req dtos.ArticlePreviewRequest) dtos.ArticlePreviewResponse {
+	descriptionHTML, toc := helpers.RenderMarkdown(req.Description)
+
+	return dtos.ArticlePreviewResponse{
+		DescriptionHTML: descriptionHTML,
+		TableOfContents: toc,
+	}
 }
 
 // DeleteArticle godoc
@@ -246,7 +840,14 @@ func (u *articleUsecase) DeleteArticle(id uint) error {
 	os.Remove(thumbnailDst)
 
 	err = u.articleRepository.DeleteArticle(article)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Remove the article from the related lists of other articles
+	u.recommender.QueueRefresh(article.ID)
+
+	return nil
 }
 
 func (u *articleUsecase) GetArticleByImage(image string) (int64, error) {
@@ -266,3 +867,266 @@ func (u *articleUsecase) GetArticleByThumbnail(thumbnail string) (int64, error)
 
 	return total, nil
 }
+
+// Save snapshot of the article content made by the administrator
+func (u *articleUsecase) saveRevision(article models.Article, administratorID uint) error {
+	var tags []string
+	for _, tag := range article.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	_, err := u.revisionRepository.CreateRevision(models.ArticleRevision{
+		ArticleID:       article.ID,
+		AdministratorID: administratorID,
+		CategoryID:      article.CategoryID,
+		Thumbnail:       article.Thumbnail,
+		Title:           article.Title,
+		Abstract:        article.Abstract,
+		Description:     article.Description,
+		Image:           article.Image,
+		Label:           article.Label,
+		Slug:            article.Slug,
+		MetaTitle:       article.MetaTitle,
+		MetaDescription: article.MetaDescription,
+		CanonicalURL:    article.CanonicalURL,
+		OGImage:         article.OGImage,
+		Tags:            strings.Join(tags, ","),
+	})
+	if err != nil {
+		return errors.New("Failed to save article revision")
+	}
+
+	return nil
+}
+
+// Create slug from title, suffixed with -2, -3 and so on when used by another article
+func (u *articleUsecase) uniqueSlug(title string, articleID uint) (string, error) {
+	base := helpers.CreateSlug(title)
+	if base == "" {
+		base = "article"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := u.articleRepository.IsSlugTaken(slug, articleID)
+		if err != nil {
+			return "", errors.New("Failed to check article slug")
+		}
+		if !taken {
+			return slug, nil
+		}
+
+		slug = base + "-" + strconv.Itoa(i)
+	}
+}
+
+func canTransitionArticle(from string, to string) bool {
+	for _, status := range articleTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Set article status, publishing date is kept from the first time the article is published
+func setArticleStatus(article *models.Article, status string) {
+	article.Status = status
+
+	if status == models.ArticlePublished && article.PublishedAt == nil {
+		now := time.Now()
+		article.PublishedAt = &now
+	}
+
+	// Published or archived article has nothing left to schedule
+	if status == models.ArticlePublished || status == models.ArticleArchived {
+		article.ScheduledAt = nil
+	}
+}
+
+// Find category from category_id, or from the legacy label for older clients
+func (u *articleUsecase) resolveCategory(categoryID uint, label string) (models.Category, error) {
+	if categoryID != 0 {
+		category, err := u.categoryRepository.GetCategoryByID(categoryID)
+		if err != nil {
+			return category, errors.New("Category not found")
+		}
+		return category, nil
+	}
+
+	if label == "" {
+		return models.Category{}, errors.New("Category is required")
+	}
+
+	category, err := u.categoryRepository.GetCategoryBySlug(helpers.CreateSlug(label))
+	if err != nil {
+		return category, errors.New("Category not found")
+	}
+
+	return category, nil
+}
+
+// Get or create tags from names, names with the same slug are merged into one tag.
+// Comma separated names are accepted for multipart form clients.
+func (u *articleUsecase) resolveTags(names []string) ([]models.Tag, error) {
+	var (
+		tags []models.Tag
+		seen = map[string]bool{}
+	)
+
+	for _, value := range names {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			slug := helpers.CreateSlug(name)
+			if slug == "" || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+
+			tag, err := u.tagRepository.FirstOrCreateTag(models.Tag{Name: name, Slug: slug})
+			if err != nil {
+				return nil, errors.New("Failed to save tag " + name)
+			}
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// Convert query filter into repository filter, category slug is resolved into ID
+func (u *articleUsecase) articleFilter(filter dtos.ArticleFilter) (repositories.ArticleFilter, error) {
+	articleFilter := repositories.ArticleFilter{
+		Status:       filter.Status,
+		BookmarkedBy: filter.BookmarkedBy,
+		AuthorID:     filter.AuthorID,
+	}
+
+	if filter.Category != "" {
+		category, err := u.categoryRepository.GetCategoryBySlug(filter.Category)
+		if err != nil {
+			return articleFilter, errors.New("Category not found")
+		}
+		articleFilter.CategoryID = category.ID
+	}
+
+	if filter.Tag != "" {
+		tag, err := u.tagRepository.GetTagBySlug(filter.Tag)
+		if err != nil {
+			return articleFilter, errors.New("Tag not found")
+		}
+		articleFilter.TagID = tag.ID
+	}
+
+	return articleFilter, nil
+}
+
+// Fill counters of a page of articles with grouped queries instead of one query per article
+func (u *articleUsecase) attachArticleCounts(articleResponses []dtos.ArticleDetailResponse) error {
+	if len(articleResponses) == 0 {
+		return nil
+	}
+
+	var ids []uint
+	for _, articleResponse := range articleResponses {
+		ids = append(ids, articleResponse.ArticleID)
+	}
+
+	commentCounts, err := u.commentRepository.CountCommentsByArticleIDs(ids, models.CommentApproved)
+	if err != nil {
+		return errors.New("Failed to count article comments")
+	}
+
+	likeCounts, err := u.likedRepository.CountLikesByArticleIDs(ids)
+	if err != nil {
+		return errors.New("Failed to count article likes")
+	}
+
+	for i := range articleResponses {
+		articleResponses[i].CommentCount = commentCounts[articleResponses[i].ArticleID]
+		articleResponses[i].LikeCount = likeCounts[articleResponses[i].ArticleID]
+	}
+
+	return nil
+}
+
+// Set liked_by_me of a page of articles for the logged in user with one query
+func (u *articleUsecase) MarkLikedByUser(userId uint, articleResponses []dtos.ArticleDetailResponse) error {
+	if len(articleResponses) == 0 {
+		return nil
+	}
+
+	var ids []uint
+	for _, articleResponse := range articleResponses {
+		ids = append(ids, articleResponse.ArticleID)
+	}
+
+	liked, err := u.likedRepository.GetLikedArticleIDs(userId, ids)
+	if err != nil {
+		return errors.New("Failed to get liked articles")
+	}
+
+	for i := range articleResponses {
+		likedByMe := liked[articleResponses[i].ArticleID]
+		articleResponses[i].LikedByMe = &likedByMe
+	}
+
+	return nil
+}
+
+// Map Article model into the response shared by every article endpoint
+func newArticleResponse(article models.Article) dtos.ArticleDetailResponse {
+	var category *dtos.ArticleCategoryResponse
+	if article.Category != nil {
+		category = &dtos.ArticleCategoryResponse{
+			CategoryID: article.Category.ID,
+			Name:       article.Category.Name,
+			Slug:       article.Category.Slug,
+			Icon:       article.Category.Icon,
+		}
+	}
+
+	tags := []dtos.ArticleTagResponse{}
+	for _, tag := range article.Tags {
+		tags = append(tags, dtos.ArticleTagResponse{
+			TagID: tag.ID,
+			Name:  tag.Name,
+			Slug:  tag.Slug,
+		})
+	}
+
+	// Open Graph image falls back to the thumbnail
+	ogImage := article.OGImage
+	if ogImage == "" {
+		ogImage = article.Thumbnail
+	}
+
+	return dtos.ArticleDetailResponse{
+		ArticleID:           article.ID,
+		Thumbnail:           article.Thumbnail,
+		Title:               article.Title,
+		Abstract:            article.Abstract,
+		Image:               article.Image,
+		Description:         article.Description,
+		DescriptionMarkdown: article.Description,
+		DescriptionHTML:     article.DescriptionHTML,
+		TableOfContents:     helpers.DecodeTOC(article.TableOfContents),
+		WordCount:           article.WordCount,
+		ReadingTime:         article.ReadingTime,
+		Views:               article.Views,
+		Label:               article.Label,
+		Category:            category,
+		Tags:                tags,
+		Slug:                article.Slug,
+		MetaTitle:           article.MetaTitle,
+		MetaDescription:     article.MetaDescription,
+		CanonicalURL:        article.CanonicalURL,
+		OGImage:             ogImage,
+		Status:              article.Status,
+		PublishedAt:         article.PublishedAt,
+		ScheduledAt:         article.ScheduledAt,
+		CreatedAt:           article.CreatedAt,
+		UpdatedAt:           article.UpdatedAt,
+	}
+}
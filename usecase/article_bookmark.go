@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"go_bedu/repositories"
+)
+
+type ArticleBookmarkUsecase interface {
+	GetBookmarks(userId uint, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+	BookmarkArticle(userId uint, articleId uint) (dtos.ArticleBookmarkResponse, error)
+	RemoveBookmark(userId uint, articleId uint) (dtos.ArticleBookmarkResponse, error)
+}
+
+type articleBookmarkUsecase struct {
+	bookmarkRepository repositories.ArticleBookmarkRepository
+	articleUsecase     ArticleUsecase
+}
+
+func NewArticleBookmarkUsecase(bookmarkRepository repositories.ArticleBookmarkRepository, articleUsecase ArticleUsecase) *articleBookmarkUsecase {
+	return &articleBookmarkUsecase{bookmarkRepository, articleUsecase}
+}
+
+// GetBookmarks godoc
+// @Summary      Get bookmarked articles
+// @Description  Get articles bookmarked by the logged in user, latest saved first, bookmarks are private
+// @Tags         User - Account
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/bookmarks [get]
+// @Security     BearerAuth
+func (u *articleBookmarkUsecase) GetBookmarks(userId uint, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	articles, count, err := u.articleUsecase.GetAllArticles(dtos.ArticleFilter{BookmarkedBy: userId}, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get bookmarks")
+	}
+
+	return articles, count, nil
+}
+
+// BookmarkArticle godoc
+// @Summary      Bookmark an article
+// @Description  Save an article to the private bookmarks, bookmarking it again keeps a single bookmark
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.ArticleBookmarkStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/bookmark [put]
+// @Security     BearerAuth
+func (u *articleBookmarkUsecase) BookmarkArticle(userId uint, articleId uint) (dtos.ArticleBookmarkResponse, error) {
+	bookmarkResponse := dtos.ArticleBookmarkResponse{ArticleID: articleId}
+
+	_, err := u.bookmarkRepository.CreateBookmark(models.ArticleBookmark{
+		UserID:    userId,
+		ArticleID: articleId,
+	})
+	if err != nil {
+		return bookmarkResponse, errors.New("Failed to bookmark article")
+	}
+
+	bookmarkResponse.Bookmarked = true
+
+	return bookmarkResponse, nil
+}
+
+// RemoveBookmark godoc
+// @Summary      Remove bookmark of an article
+// @Description  Remove an article from the private bookmarks, removing a missing bookmark succeeds
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.ArticleBookmarkStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/bookmark [delete]
+// @Security     BearerAuth
+func (u *articleBookmarkUsecase) RemoveBookmark(userId uint, articleId uint) (dtos.ArticleBookmarkResponse, error) {
+	bookmarkResponse := dtos.ArticleBookmarkResponse{ArticleID: articleId}
+
+	err := u.bookmarkRepository.DeleteBookmark(userId, articleId)
+	if err != nil {
+		return bookmarkResponse, errors.New("Failed to remove bookmark")
+	}
+
+	return bookmarkResponse, nil
+}
@@ -2,28 +2,45 @@ package usecase
 
 import (
 	"errors"
+	"go_bedu/dtos"
 	"go_bedu/models"
 	"go_bedu/repositories"
+	"log"
+	"time"
 )
 
+// Called when a user likes an article that was not liked by the user yet
+type ArticleLikedHandler func(userId uint, articleId uint, likedAt time.Time) error
+
 type ArticleLikedUsecase interface {
 	GetArticleLikedByUserId(userId uint) ([]models.ArticleLiked, error)
 	GetArticleLikeByUserIdAndArticleId(userId uint, articleId uint) (models.ArticleLiked, error)
-	CreateArticleLiked(id uint, articleId uint) (models.ArticleLiked, error)
+	LikeArticle(userId uint, articleId uint) (dtos.ArticleLikeResponse, error)
+	UnlikeArticle(userId uint, articleId uint) (dtos.ArticleLikeResponse, error)
+	OnArticleLiked(handler ArticleLikedHandler)
 }
 
 type articleLikedUsecase struct {
 	articleLikedRepo repositories.ArticleLikedRepository
 	userRepository   repositories.UserRepository
+	likedHandlers    []ArticleLikedHandler
 }
 
 func NewArticleLikedUsecase(articleLikedRepo repositories.ArticleLikedRepository, userRepository repositories.UserRepository) *articleLikedUsecase {
-	return &articleLikedUsecase{articleLikedRepo, userRepository}
+	return &articleLikedUsecase{
+		articleLikedRepo: articleLikedRepo,
+		userRepository:   userRepository,
+	}
+}
+
+// Register a handler for new likes, handlers are registered while wiring routes
+func (u *articleLikedUsecase) OnArticleLiked(handler ArticleLikedHandler) {
+	u.likedHandlers = append(u.likedHandlers, handler)
 }
 
-// GetBookmarkArticle godoc
-// @Summary      Get Bookmark by User ID
-// @Description  Get Bookmark by User ID
+// GetLikedArticle godoc
+// @Summary      Get liked articles by User ID
+// @Description  Get articles liked by the logged in user
 // @Tags         User - Account
 // @Accept       json
 // @Produce      json
@@ -61,33 +78,82 @@ func (u *articleLikedUsecase) GetArticleLikeByUserIdAndArticleId(userId uint, ar
 	return articleLiked, nil
 }
 
-// CreateBookmarkArticle godoc
-// @Summary      Create Bookmark by Article ID
-// @Description  Create Bookmark by Article ID
+// LikeArticle godoc
+// @Summary      Like an article
+// @Description  Like an article, liking it again keeps a single like
 // @Tags         Article
 // @Accept       json
 // @Produce      json
 // @Param id path integer true "ID article"
-// @Success      200 {object} dtos.LikedStatusOKResponse
+// @Success      200 {object} dtos.ArticleLikeStatusOKResponse
 // @Failure      400 {object} dtos.BadRequestResponse
 // @Failure      401 {object} dtos.UnauthorizedResponse
 // @Failure      403 {object} dtos.ForbiddenResponse
 // @Failure      404 {object} dtos.NotFoundResponse
 // @Failure      500 {object} dtos.InternalServerErrorResponse
-// @Router       /article/like/{id} [get]
+// @Router       /article/{id}/like [put]
 // @Security     BearerAuth
-func (u *articleLikedUsecase) CreateArticleLiked(id uint, articleId uint) (models.ArticleLiked, error) {
-	articleLiked := models.ArticleLiked{
+func (u *articleLikedUsecase) LikeArticle(userId uint, articleId uint) (dtos.ArticleLikeResponse, error) {
+	articleLike := dtos.ArticleLikeResponse{ArticleID: articleId}
+
+	// Liking twice keeps the first like, handlers only run for the first one
+	liked, created, err := u.articleLikedRepo.CreateArticleLiked(models.ArticleLiked{
 		ArticleID: articleId,
-		UserID:    id,
+		UserID:    userId,
+	})
+	if err != nil {
+		return articleLike, errors.New("Failed to like article")
+	}
+	if created {
+		u.notifyArticleLiked(userId, articleId, liked.CreatedAt)
 	}
 
-	CountArticle, _ := u.articleLikedRepo.GetLikeByUserIdAndArticleId(uint(id), uint(articleId))
-	if CountArticle.ID != 0 {
-		articleLiked, _ = u.articleLikedRepo.DeleteArticleLiked(id, articleId)
-	} else {
-		articleLiked, _ = u.articleLikedRepo.CreateArticleLiked(articleLiked)
+	articleLike.Liked = true
+	articleLike.LikeCount, err = u.articleLikedRepo.CountArticleLikes(articleId)
+	if err != nil {
+		return articleLike, errors.New("Failed to count article likes")
 	}
 
-	return articleLiked, nil
+	return articleLike, nil
+}
+
+// UnlikeArticle godoc
+// @Summary      Unlike an article
+// @Description  Remove like of an article, removing a missing like succeeds
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.ArticleLikeStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/like [delete]
+// @Security     BearerAuth
+func (u *articleLikedUsecase) UnlikeArticle(userId uint, articleId uint) (dtos.ArticleLikeResponse, error) {
+	articleLike := dtos.ArticleLikeResponse{ArticleID: articleId}
+
+	_, err := u.articleLikedRepo.DeleteArticleLiked(userId, articleId)
+	if err != nil {
+		return articleLike, errors.New("Failed to unlike article")
+	}
+
+	articleLike.LikeCount, err = u.articleLikedRepo.CountArticleLikes(articleId)
+	if err != nil {
+		return articleLike, errors.New("Failed to count article likes")
+	}
+
+	return articleLike, nil
+}
+
+// Like is already saved, a failing handler is logged
+func (u *articleLikedUsecase) notifyArticleLiked(userId uint, articleId uint, likedAt time.Time) {
+	for _, handler := range u.likedHandlers {
+		err := handler(userId, articleId, likedAt)
+		if err != nil {
+			log.Printf("article liked: liked handler: user %d article %d: %v", userId, articleId, err)
+		}
+	}
 }
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// Latest unread articles considered for the personalized feed
+	personalFeedPool = 500
+	// Recency loses half of its weight every this many days
+	personalFeedHalfLifeDays = 14.0
+
+	affinityWeight = 0.7
+	recencyWeight  = 0.3
+)
+
+// Share of the user likes per category, tag and author, followed categories and authors count in full
+type readerInterests struct {
+	categories map[uint]float64
+	tags       map[uint]float64
+	authors    map[uint]float64
+}
+
+// GetPersonalizedFeed godoc
+// @Summary      Get personalized article feed
+// @Description  Get published articles ranked by the categories, tags and authors of articles the user liked, by followed categories and authors and by recency. Liked articles are excluded
+// @Tags         User - Account
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/feed [get]
+// @Security     BearerAuth
+func (u *articleUsecase) GetPersonalizedFeed(userId uint, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	liked, err := u.articleRepository.GetArticlesLikedByUser(userId)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get liked articles")
+	}
+
+	followedAuthors, err := u.followRepository.GetFollowedAuthors(userId)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get followed authors")
+	}
+
+	followedCategories, err := u.followRepository.GetFollowedCategories(userId)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get followed categories")
+	}
+
+	candidates, err := u.articleRepository.GetFeedCandidates(userId, personalFeedPool)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get articles")
+	}
+
+	interests := newReaderInterests(liked, followedAuthors, followedCategories)
+	now := time.Now()
+
+	scores := make(map[uint]float64, len(candidates))
+	for _, article := range candidates {
+		scores[article.ID] = affinityWeight*interests.affinity(article) + recencyWeight*recency(article, now)
+	}
+
+	// Stable sort keeps newer articles first on equal score
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return scores[candidates[a].ID] > scores[candidates[b].ID]
+	})
+
+	count := len(candidates)
+	offset := (page - 1) * limit
+	if offset >= count {
+		return []dtos.ArticleDetailResponse{}, count, nil
+	}
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+
+	var articleResponses []dtos.ArticleDetailResponse
+	for _, article := range candidates[offset:end] {
+		articleResponse := newArticleResponse(article)
+		articleResponse.Score = math.Round(scores[article.ID]*1000) / 1000
+		articleResponses = append(articleResponses, articleResponse)
+	}
+
+	err = u.attachArticleCounts(articleResponses)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articleResponses, count, nil
+}
+
+func newReaderInterests(liked []models.Article, followedAuthors []models.AuthorFollow, followedCategories []models.CategoryFollow) readerInterests {
+	interests := readerInterests{
+		categories: make(map[uint]float64),
+		tags:       make(map[uint]float64),
+		authors:    make(map[uint]float64),
+	}
+
+	if len(liked) > 0 {
+		share := 1 / float64(len(liked))
+		for _, article := range liked {
+			if article.CategoryID != nil {
+				interests.categories[*article.CategoryID] += share
+			}
+			for _, tag := range article.Tags {
+				interests.tags[tag.ID] += share
+			}
+			interests.authors[article.AdministratorID] += share
+		}
+	}
+
+	// Following is an explicit interest, as strong as liking only that category or author
+	for _, follow := range followedAuthors {
+		interests.authors[follow.AdministratorID] = 1
+	}
+	for _, follow := range followedCategories {
+		interests.categories[follow.CategoryID] = 1
+	}
+
+	return interests
+}
+
+// How close the article is to the reader interests, between 0 and 1
+func (i readerInterests) affinity(article models.Article) float64 {
+	var category, tags float64
+	if article.CategoryID != nil {
+		category = i.categories[*article.CategoryID]
+	}
+	for _, tag := range article.Tags {
+		tags += i.tags[tag.ID]
+	}
+
+	return 0.5*category + 0.3*math.Min(tags, 1) + 0.2*i.authors[article.AdministratorID]
+}
+
+// Recency between 0 and 1, halved every half life
+func recency(article models.Article, now time.Time) float64 {
+	published := article.CreatedAt
+	if article.PublishedAt != nil {
+		published = *article.PublishedAt
+	}
+
+	age := now.Sub(published).Hours() / 24
+	if age < 0 {
+		age = 0
+	}
+
+	return math.Pow(0.5, age/personalFeedHalfLifeDays)
+}
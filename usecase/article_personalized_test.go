@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"go_bedu/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaderInterests(t *testing.T) {
+	followed, other := uint(1), uint(2)
+
+	t.Run("Test Followed Author Outranks Not Followed", func(t *testing.T) {
+		interests := newReaderInterests(nil, []models.AuthorFollow{{AdministratorID: followed}}, nil)
+
+		assert.Greater(t,
+			interests.affinity(models.Article{AdministratorID: followed}),
+			interests.affinity(models.Article{AdministratorID: other}),
+		)
+	})
+
+	t.Run("Test Followed Category Outranks Not Followed", func(t *testing.T) {
+		interests := newReaderInterests(nil, nil, []models.CategoryFollow{{CategoryID: followed}})
+
+		assert.Greater(t,
+			interests.affinity(models.Article{CategoryID: &followed}),
+			interests.affinity(models.Article{CategoryID: &other}),
+		)
+	})
+
+	t.Run("Test Followed Category Outranks Liked Share", func(t *testing.T) {
+		liked := []models.Article{{CategoryID: &other}, {CategoryID: &followed}, {CategoryID: &other}}
+		interests := newReaderInterests(liked, nil, []models.CategoryFollow{{CategoryID: followed}})
+
+		assert.Equal(t, 1.0, interests.categories[followed])
+		assert.InDelta(t, 2.0/3, interests.categories[other], 0.001)
+	})
+}
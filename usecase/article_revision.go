@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"strconv"
+	"strings"
+)
+
+type ArticleRevisionUsecase interface {
+	GetRevisions(articleId uint) ([]dtos.ArticleRevisionResponse, error)
+	DiffRevisions(articleId uint, from int, to int) (dtos.ArticleRevisionDiffResponse, error)
+	RestoreRevision(articleId uint, revision int, administratorId uint) (dtos.ArticleDetailResponse, error)
+}
+
+type articleRevisionUsecase struct {
+	revisionRepository repositories.ArticleRevisionRepository
+	articleRepository  repositories.ArticleRepository
+	categoryRepository repositories.CategoryRepository
+	articleUsecase     ArticleUsecase
+}
+
+func NewArticleRevisionUsecase(revisionRepository repositories.ArticleRevisionRepository, articleRepository repositories.ArticleRepository, categoryRepository repositories.CategoryRepository, articleUsecase ArticleUsecase) ArticleRevisionUsecase {
+	return &articleRevisionUsecase{revisionRepository, articleRepository, categoryRepository, articleUsecase}
+}
+
+// GetArticleRevisions godoc
+// @Summary      Get article revisions
+// @Description  Get revision history of an article, newest first
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.GetAllArticleRevisionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/revisions [get]
+// @Security BearerAuth
+func (u *articleRevisionUsecase) GetRevisions(articleId uint) ([]dtos.ArticleRevisionResponse, error) {
+	_, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil {
+		return nil, errors.New("Failed to get article")
+	}
+
+	revisions, err := u.revisionRepository.GetRevisions(articleId)
+	if err != nil {
+		return nil, errors.New("Failed to get article revisions")
+	}
+
+	revisionResponses := []dtos.ArticleRevisionResponse{}
+	for _, revision := range revisions {
+		revisionResponses = append(revisionResponses, newArticleRevisionResponse(revision))
+	}
+
+	return revisionResponses, nil
+}
+
+// DiffArticleRevisions godoc
+// @Summary      Compare two article revisions
+// @Description  Get changed fields between two revisions with line level diff for abstract and description
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param from query int true "Older revision number"
+// @Param to query int true "Newer revision number"
+// @Success      200 {object} dtos.ArticleRevisionDiffStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/revisions/diff [get]
+// @Security BearerAuth
+func (u *articleRevisionUsecase) DiffRevisions(articleId uint, from int, to int) (dtos.ArticleRevisionDiffResponse, error) {
+	diff := dtos.ArticleRevisionDiffResponse{
+		ArticleID: articleId,
+		From:      from,
+		To:        to,
+		Changes:   []dtos.ArticleFieldDiff{},
+	}
+
+	fromRevision, err := u.revisionRepository.GetRevision(articleId, from)
+	if err != nil {
+		return diff, errors.New("Revision " + strconv.Itoa(from) + " not found")
+	}
+
+	toRevision, err := u.revisionRepository.GetRevision(articleId, to)
+	if err != nil {
+		return diff, errors.New("Revision " + strconv.Itoa(to) + " not found")
+	}
+
+	fields := []struct {
+		name      string
+		from, to  string
+		lineLevel bool
+	}{
+		{"title", fromRevision.Title, toRevision.Title, false},
+		{"abstract", fromRevision.Abstract, toRevision.Abstract, true},
+		{"description", fromRevision.Description, toRevision.Description, true},
+		{"label", fromRevision.Label, toRevision.Label, false},
+		{"slug", fromRevision.Slug, toRevision.Slug, false},
+		{"tags", fromRevision.Tags, toRevision.Tags, false},
+		{"meta_title", fromRevision.MetaTitle, toRevision.MetaTitle, false},
+		{"meta_description", fromRevision.MetaDescription, toRevision.MetaDescription, false},
+		{"canonical_url", fromRevision.CanonicalURL, toRevision.CanonicalURL, false},
+		{"og_image", fromRevision.OGImage, toRevision.OGImage, false},
+		{"thumbnail", fromRevision.Thumbnail, toRevision.Thumbnail, false},
+		{"image", fromRevision.Image, toRevision.Image, false},
+		{"category_id", uintPtrString(fromRevision.CategoryID), uintPtrString(toRevision.CategoryID), false},
+	}
+
+	for _, field := range fields {
+		if field.from == field.to {
+			continue
+		}
+
+		change := dtos.ArticleFieldDiff{
+			Field: field.name,
+			From:  field.from,
+			To:    field.to,
+		}
+		if field.lineLevel {
+			change.Lines = helpers.LineDiff(field.from, field.to)
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	return diff, nil
+}
+
+// RestoreArticleRevision godoc
+// @Summary      Restore article revision
+// @Description  Restore article content from a revision, the restore is saved as a new revision
+// @Tags         Admin - Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param rev path integer true "Revision number"
+// @Success      200 {object} dtos.ArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/revisions/{rev}/restore [post]
+// @Security BearerAuth
+func (u *articleRevisionUsecase) RestoreRevision(articleId uint, revision int, administratorId uint) (dtos.ArticleDetailResponse, error) {
+	var articleResponse dtos.ArticleDetailResponse
+
+	article, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil {
+		return articleResponse, errors.New("Failed to get article")
+	}
+
+	articleRevision, err := u.revisionRepository.GetRevision(articleId, revision)
+	if err != nil {
+		return articleResponse, errors.New("Revision not found")
+	}
+
+	// Keep current category when the category of the revision was deleted
+	var categoryId uint
+	if article.CategoryID != nil {
+		categoryId = *article.CategoryID
+	}
+	if articleRevision.CategoryID != nil {
+		if _, err := u.categoryRepository.GetCategoryByID(*articleRevision.CategoryID); err == nil {
+			categoryId = *articleRevision.CategoryID
+		}
+	}
+
+	tags := []string{}
+	if articleRevision.Tags != "" {
+		tags = strings.Split(articleRevision.Tags, ",")
+	}
+
+	return u.articleUsecase.UpdateArticle(articleId, dtos.UpdateArticlesRequest{
+		AdministratorID: administratorId,
+		CategoryID:      categoryId,
+		Thumbnail:       articleRevision.Thumbnail,
+		Title:           articleRevision.Title,
+		Abstract:        articleRevision.Abstract,
+		Description:     articleRevision.Description,
+		Image:           articleRevision.Image,
+		Label:           articleRevision.Label,
+		Tags:            tags,
+		MetaTitle:       &articleRevision.MetaTitle,
+		MetaDescription: &articleRevision.MetaDescription,
+		CanonicalURL:    &articleRevision.CanonicalURL,
+		OGImage:         &articleRevision.OGImage,
+	})
+}
+
+// Map ArticleRevision model into revision response
+func newArticleRevisionResponse(revision models.ArticleRevision) dtos.ArticleRevisionResponse {
+	tags := []string{}
+	if revision.Tags != "" {
+		tags = strings.Split(revision.Tags, ",")
+	}
+
+	return dtos.ArticleRevisionResponse{
+		RevisionID:        revision.ID,
+		ArticleID:         revision.ArticleID,
+		Revision:          revision.Revision,
+		AdministratorID:   revision.AdministratorID,
+		AdministratorName: revision.Administrator.Nama,
+		CategoryID:        revision.CategoryID,
+		Thumbnail:         revision.Thumbnail,
+		Title:             revision.Title,
+		Abstract:          revision.Abstract,
+		Description:       revision.Description,
+		Image:             revision.Image,
+		Label:             revision.Label,
+		Slug:              revision.Slug,
+		MetaTitle:         revision.MetaTitle,
+		MetaDescription:   revision.MetaDescription,
+		CanonicalURL:      revision.CanonicalURL,
+		OGImage:           revision.OGImage,
+		Tags:              tags,
+		CreatedAt:         revision.CreatedAt,
+	}
+}
+
+func uintPtrString(value *uint) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(*value), 10)
+}
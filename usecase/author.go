@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/repositories"
+)
+
+type AuthorUsecase interface {
+	GetAuthor(userId uint, username string, page, limit int) (dtos.AuthorResponse, int, error)
+}
+
+type authorUsecase struct {
+	adminRepository  repositories.AdminRepository
+	followRepository repositories.FollowRepository
+	articleUsecase   ArticleUsecase
+}
+
+func NewAuthorUsecase(adminRepository repositories.AdminRepository, followRepository repositories.FollowRepository, articleUsecase ArticleUsecase) AuthorUsecase {
+	return &authorUsecase{adminRepository, followRepository, articleUsecase}
+}
+
+// GetAuthor godoc
+// @Summary      Get author profile
+// @Description  Get the public profile of an administrator with their published articles, logged in users also get whether they follow the author
+// @Tags         Author
+// @Accept       json
+// @Produce      json
+// @Param username path string true "Username of the author"
+// @Param page query int false "Page number of the articles"
+// @Param limit query int false "Number of articles per page"
+// @Success      200 {object} dtos.AuthorStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /author/{username} [get]
+func (u *authorUsecase) GetAuthor(userId uint, username string, page, limit int) (dtos.AuthorResponse, int, error) {
+	admin, err := u.adminRepository.GetAdminByUsername(username)
+	if err != nil {
+		return dtos.AuthorResponse{}, 0, errors.New("Author not found")
+	}
+
+	followers, err := u.followRepository.CountAuthorFollowers([]uint{admin.ID})
+	if err != nil {
+		return dtos.AuthorResponse{}, 0, errors.New("Failed to count followers")
+	}
+
+	// Only published articles are listed and counted
+	articles, count, err := u.articleUsecase.GetAllArticles(dtos.ArticleFilter{AuthorID: admin.ID}, page, limit)
+	if err != nil {
+		return dtos.AuthorResponse{}, 0, err
+	}
+	if articles == nil {
+		articles = []dtos.ArticleDetailResponse{}
+	}
+
+	author := dtos.AuthorResponse{
+		Username:      admin.Username,
+		Name:          admin.Nama,
+		PhotoProfile:  admin.PhotoProfile,
+		Bio:           admin.Bio,
+		SocialLinks:   newSocialLinksResponse(admin),
+		FollowerCount: followers[admin.ID],
+		ArticleCount:  count,
+		Articles:      articles,
+	}
+
+	if userId != 0 {
+		following, err := u.followRepository.IsFollowingAuthor(userId, admin.ID)
+		if err != nil {
+			return dtos.AuthorResponse{}, 0, errors.New("Failed to get follow")
+		}
+		author.FollowedByMe = &following
+	}
+
+	return author, count, nil
+}
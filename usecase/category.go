@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+)
+
+type CategoryUsecase interface {
+	GetCategories() ([]dtos.CategoryResponse, error)
+	GetCategoryBySlug(slug string) (dtos.CategoryResponse, error)
+	CreateCategory(req dtos.CategoryRequest) (dtos.CategoryResponse, error)
+	UpdateCategory(id uint, req dtos.CategoryRequest) (dtos.CategoryResponse, error)
+	DeleteCategory(id uint) error
+}
+
+type categoryUsecase struct {
+	categoryRepository repositories.CategoryRepository
+}
+
+func NewCategoryUsecase(categoryRepository repositories.CategoryRepository) CategoryUsecase {
+	return &categoryUsecase{categoryRepository}
+}
+
+// GetAllCategories godoc
+// @Summary      Get all categories
+// @Description  Get all categories with their article count
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.GetAllCategoryStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /category [get]
+func (u *categoryUsecase) GetCategories() ([]dtos.CategoryResponse, error) {
+	categories, err := u.categoryRepository.GetCategories()
+	if err != nil {
+		return nil, errors.New("Failed to get categories")
+	}
+
+	var categoryResponses []dtos.CategoryResponse
+	for _, category := range categories {
+		categoryResponse := newCategoryResponse(category.Category)
+		categoryResponse.ArticleCount = category.ArticleCount
+		categoryResponses = append(categoryResponses, categoryResponse)
+	}
+
+	return categoryResponses, nil
+}
+
+func (u *categoryUsecase) GetCategoryBySlug(slug string) (dtos.CategoryResponse, error) {
+	var categoryResponse dtos.CategoryResponse
+
+	category, err := u.categoryRepository.GetCategoryBySlug(slug)
+	if err != nil {
+		return categoryResponse, errors.New("Category not found")
+	}
+
+	return newCategoryResponse(category), nil
+}
+
+// CreateCategory godoc
+// @Summary      Create a new category
+// @Description  Create a new category
+// @Tags         Admin - Category
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.CategoryRequest true "Payload Body [RAW]"
+// @Success      201 {object} dtos.CategoryCreatedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/category [post]
+// @Security BearerAuth
+func (u *categoryUsecase) CreateCategory(req dtos.CategoryRequest) (dtos.CategoryResponse, error) {
+	var categoryResponse dtos.CategoryResponse
+
+	slug := helpers.CreateSlug(req.Name)
+	if slug == "" {
+		return categoryResponse, errors.New("Category name is not valid")
+	}
+
+	existing, _ := u.categoryRepository.GetCategoryBySlug(slug)
+	if existing.ID != 0 {
+		return categoryResponse, errors.New("Category already exists")
+	}
+
+	category, err := u.categoryRepository.CreateCategory(models.Category{
+		Name:        req.Name,
+		Slug:        slug,
+		Description: req.Description,
+		Icon:        req.Icon,
+	})
+	if err != nil {
+		return categoryResponse, errors.New("Failed to create category")
+	}
+
+	return newCategoryResponse(category), nil
+}
+
+// UpdateCategory godoc
+// @Summary      Update category
+// @Description  Update category
+// @Tags         Admin - Category
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID category"
+// @Param        request body dtos.CategoryRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.CategoryStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/category/{id} [put]
+// @Security BearerAuth
+func (u *categoryUsecase) UpdateCategory(id uint, req dtos.CategoryRequest) (dtos.CategoryResponse, error) {
+	var categoryResponse dtos.CategoryResponse
+
+	category, err := u.categoryRepository.GetCategoryByID(id)
+	if err != nil {
+		return categoryResponse, errors.New("Category not found")
+	}
+
+	slug := helpers.CreateSlug(req.Name)
+	if slug == "" {
+		return categoryResponse, errors.New("Category name is not valid")
+	}
+
+	existing, _ := u.categoryRepository.GetCategoryBySlug(slug)
+	if existing.ID != 0 && existing.ID != category.ID {
+		return categoryResponse, errors.New("Category already exists")
+	}
+
+	category.Name = req.Name
+	category.Slug = slug
+	category.Description = req.Description
+	category.Icon = req.Icon
+
+	category, err = u.categoryRepository.UpdateCategory(category)
+	if err != nil {
+		return categoryResponse, errors.New("Failed to update category")
+	}
+
+	return newCategoryResponse(category), nil
+}
+
+// DeleteCategory godoc
+// @Summary      Delete a category
+// @Description  Delete a category that is no longer used by any article
+// @Tags         Admin - Category
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID category"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/category/{id} [delete]
+// @Security BearerAuth
+func (u *categoryUsecase) DeleteCategory(id uint) error {
+	category, err := u.categoryRepository.GetCategoryByID(id)
+	if err != nil {
+		return errors.New("Category not found")
+	}
+
+	total, err := u.categoryRepository.CountArticlesByCategory(category.ID)
+	if err != nil {
+		return errors.New("Failed to count category articles")
+	}
+
+	if total > 0 {
+		return errors.New("Category is still used by articles")
+	}
+
+	return u.categoryRepository.DeleteCategory(category)
+}
+
+// Map Category model into category response
+func newCategoryResponse(category models.Category) dtos.CategoryResponse {
+	return dtos.CategoryResponse{
+		CategoryID:  category.ID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+		Icon:        category.Icon,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/initializers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"os"
+	"strings"
+	"time"
+)
+
+// Certificates are stored next to the images and served from /public
+const certificateDir = "public/certificates/"
+
+type CertificateUsecase interface {
+	GetCertificates(userId uint, page, limit int) ([]dtos.CertificateResponse, int, error)
+	VerifyCertificate(code string) (dtos.CertificateVerifyResponse, error)
+	IssueCertificate(userId uint, courseId uint, completedAt time.Time) error
+}
+
+type certificateUsecase struct {
+	certificateRepository repositories.CertificateRepository
+	courseRepository      repositories.CourseRepository
+	userRepository        repositories.UserRepository
+}
+
+func NewCertificateUsecase(certificateRepository repositories.CertificateRepository, courseRepository repositories.CourseRepository, userRepository repositories.UserRepository) CertificateUsecase {
+	return &certificateUsecase{certificateRepository, courseRepository, userRepository}
+}
+
+// GetCertificates godoc
+// @Summary      Get certificates
+// @Description  Get course completion certificates of the logged in user, latest issued first
+// @Tags         User - Certificate
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCertificateStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/certificates [get]
+// @Security     BearerAuth
+func (u *certificateUsecase) GetCertificates(userId uint, page, limit int) ([]dtos.CertificateResponse, int, error) {
+	certificates, count, err := u.certificateRepository.GetCertificates(userId, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get certificates")
+	}
+
+	var certificateResponses []dtos.CertificateResponse
+	for _, certificate := range certificates {
+		certificateResponses = append(certificateResponses, dtos.CertificateResponse{
+			CertificateID:    certificate.ID,
+			CourseID:         certificate.CourseID,
+			CourseTitle:      certificate.CourseTitle,
+			FullName:         certificate.FullName,
+			VerificationCode: certificate.VerificationCode,
+			FileURL:          "/" + certificate.File,
+			IssuedAt:         certificate.IssuedAt,
+		})
+	}
+
+	return certificateResponses, count, nil
+}
+
+// VerifyCertificate godoc
+// @Summary      Verify a certificate
+// @Description  Confirm a certificate was issued by checking its verification code
+// @Tags         Certificate
+// @Accept       json
+// @Produce      json
+// @Param code path string true "Verification code"
+// @Success      200 {object} dtos.CertificateVerifyStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /certificate/verify/{code} [get]
+func (u *certificateUsecase) VerifyCertificate(code string) (dtos.CertificateVerifyResponse, error) {
+	certificate, err := u.certificateRepository.GetCertificateByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return dtos.CertificateVerifyResponse{}, errors.New("Certificate not found")
+	}
+
+	return dtos.CertificateVerifyResponse{
+		Valid:            true,
+		VerificationCode: certificate.VerificationCode,
+		FullName:         certificate.FullName,
+		CourseTitle:      certificate.CourseTitle,
+		IssuedAt:         certificate.IssuedAt,
+	}, nil
+}
+
+// Course completion handler, issues the certificate once per user and course
+func (u *certificateUsecase) IssueCertificate(userId uint, courseId uint, completedAt time.Time) error {
+	_, err := u.certificateRepository.GetCertificate(userId, courseId)
+	if err == nil {
+		return nil
+	}
+
+	user, err := u.userRepository.GetUserById(userId)
+	if err != nil {
+		return errors.New("Failed to get user")
+	}
+
+	course, err := u.courseRepository.GetCourseByID(courseId)
+	if err != nil {
+		return errors.New("Course not found")
+	}
+
+	code, err := helpers.GenerateRandomToken(8)
+	if err != nil {
+		return errors.New("Failed to generate verification code")
+	}
+	code = strings.ToUpper(code)
+
+	config, err := initializers.LoadConfig(".")
+	if err != nil {
+		return errors.New("Failed to load config")
+	}
+
+	fullName := user.FullName
+	if fullName == "" {
+		fullName = user.Username
+	}
+
+	certificate := models.Certificate{
+		UserID:           userId,
+		CourseID:         courseId,
+		VerificationCode: code,
+		FullName:         fullName,
+		CourseTitle:      course.Title,
+		File:             certificateDir + code + ".pdf",
+		IssuedAt:         completedAt,
+	}
+
+	err = os.MkdirAll(certificateDir, 0755)
+	if err != nil {
+		return errors.New("Failed to create certificate directory")
+	}
+
+	pdf := certificatePDF(certificate, config.ClientOrigin+"/#/certificate/verify/"+code)
+	err = os.WriteFile(certificate.File, pdf, 0644)
+	if err != nil {
+		return errors.New("Failed to save certificate")
+	}
+
+	saved, err := u.certificateRepository.CreateCertificate(certificate)
+	if err != nil || saved.VerificationCode != code {
+		// Another completion issued the certificate first
+		os.Remove(certificate.File)
+	}
+	if err != nil {
+		return errors.New("Failed to create certificate")
+	}
+
+	return nil
+}
+
+// A4 landscape certificate with the verification code printed at the bottom
+func certificatePDF(certificate models.Certificate, verifyURL string) []byte {
+	doc := helpers.NewPDFDocument(842, 595)
+
+	doc.Rect(30, 30, 782, 535, 3)
+	doc.Rect(40, 40, 762, 515, 1)
+
+	doc.CenteredText(470, 36, true, "Certificate of Completion")
+	doc.CenteredText(410, 14, false, "This certifies that")
+	doc.CenteredText(350, certificateTextSize(certificate.FullName, 30), true, certificate.FullName)
+	doc.Line(221, 338, 621, 338, 1)
+	doc.CenteredText(300, 14, false, "has successfully completed the course")
+	doc.CenteredText(255, certificateTextSize(certificate.CourseTitle, 22), true, certificate.CourseTitle)
+	doc.CenteredText(210, 14, false, "Completed on "+certificate.IssuedAt.Format("2 January 2006"))
+
+	doc.CenteredText(100, 11, false, "Verification code: "+certificate.VerificationCode)
+	doc.CenteredText(82, 9, false, "Verify this certificate at "+verifyURL)
+
+	return doc.Bytes()
+}
+
+// Shrink long bold text to stay inside the border
+func certificateTextSize(text string, size float64) float64 {
+	maxWidth := 700.0
+
+	width := helpers.PDFTextWidth(text, size, true)
+	if width <= maxWidth {
+		return size
+	}
+
+	return size * maxWidth / width
+}
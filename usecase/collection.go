@@ -0,0 +1,466 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+)
+
+type CollectionUsecase interface {
+	GetCollections(userId uint, page, limit int) ([]dtos.CollectionResponse, int, error)
+	GetCollection(userId uint, id uint) (dtos.CollectionResponse, error)
+	GetSharedCollection(token string) (dtos.CollectionResponse, error)
+	CreateCollection(userId uint, req dtos.CollectionRequest) (dtos.CollectionResponse, error)
+	UpdateCollection(userId uint, id uint, req dtos.CollectionRequest) (dtos.CollectionResponse, error)
+	DeleteCollection(userId uint, id uint) error
+	AddCollectionItem(userId uint, id uint, req dtos.CollectionItemRequest) (dtos.CollectionResponse, error)
+	RemoveCollectionItem(userId uint, id uint, articleId uint) (dtos.CollectionResponse, error)
+	ReorderCollectionItems(userId uint, id uint, req dtos.CollectionOrderRequest) (dtos.CollectionResponse, error)
+	ShareCollection(userId uint, id uint) (dtos.CollectionResponse, error)
+	UnshareCollection(userId uint, id uint) (dtos.CollectionResponse, error)
+}
+
+type collectionUsecase struct {
+	collectionRepository repositories.CollectionRepository
+	articleUsecase       ArticleUsecase
+	shareURL             string
+}
+
+// Share links point to the client, the token is appended to clientOrigin/#/collection/
+func NewCollectionUsecase(collectionRepository repositories.CollectionRepository, articleUsecase ArticleUsecase, clientOrigin string) CollectionUsecase {
+	return &collectionUsecase{collectionRepository, articleUsecase, clientOrigin + "/#/collection/"}
+}
+
+// GetCollections godoc
+// @Summary      Get collections
+// @Description  Get reading lists of the logged in user with the number of articles in each
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections [get]
+// @Security     BearerAuth
+func (u *collectionUsecase) GetCollections(userId uint, page, limit int) ([]dtos.CollectionResponse, int, error) {
+	collections, count, err := u.collectionRepository.GetCollectionsByUser(userId, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get collections")
+	}
+
+	var ids []uint
+	for _, collection := range collections {
+		ids = append(ids, collection.ID)
+	}
+
+	itemCounts, err := u.collectionRepository.CountCollectionItems(ids)
+	if err != nil {
+		return nil, 0, errors.New("Failed to count collection items")
+	}
+
+	var collectionResponses []dtos.CollectionResponse
+	for _, collection := range collections {
+		collectionResponse := newCollectionResponse(collection, u.shareURL)
+		collectionResponse.ItemCount = itemCounts[collection.ID]
+		collectionResponses = append(collectionResponses, collectionResponse)
+	}
+
+	return collectionResponses, count, nil
+}
+
+// GetCollection godoc
+// @Summary      Get a collection
+// @Description  Get a reading list of the logged in user with its articles in order
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id} [get]
+// @Security     BearerAuth
+func (u *collectionUsecase) GetCollection(userId uint, id uint) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// GetSharedCollection godoc
+// @Summary      Get a shared collection
+// @Description  Get a reading list shared by its owner through the share link
+// @Tags         Collection
+// @Accept       json
+// @Produce      json
+// @Param token path string true "Share token"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /collection/shared/{token} [get]
+func (u *collectionUsecase) GetSharedCollection(token string) (dtos.CollectionResponse, error) {
+	collection, err := u.collectionRepository.GetCollectionByShareToken(token)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Collection not found")
+	}
+
+	collectionResponse, err := u.collectionDetail(collection)
+	if err != nil {
+		return collectionResponse, err
+	}
+
+	collectionResponse.Owner = collection.User.Username
+
+	return collectionResponse, nil
+}
+
+// CreateCollection godoc
+// @Summary      Create a collection
+// @Description  Create an empty reading list, the collection is private until shared
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.CollectionRequest true "Payload Body [RAW]"
+// @Success      201 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections [post]
+// @Security     BearerAuth
+func (u *collectionUsecase) CreateCollection(userId uint, req dtos.CollectionRequest) (dtos.CollectionResponse, error) {
+	collection, err := u.collectionRepository.CreateCollection(models.Collection{
+		UserID:      userId,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to create collection")
+	}
+
+	return newCollectionResponse(collection, ""), nil
+}
+
+// UpdateCollection godoc
+// @Summary      Update a collection
+// @Description  Rename a reading list or change its description
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Param        request body dtos.CollectionRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id} [put]
+// @Security     BearerAuth
+func (u *collectionUsecase) UpdateCollection(userId uint, id uint, req dtos.CollectionRequest) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	collection.Name = req.Name
+	collection.Description = req.Description
+
+	collection, err = u.collectionRepository.UpdateCollection(collection)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to update collection")
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// DeleteCollection godoc
+// @Summary      Delete a collection
+// @Description  Delete a reading list, the articles themselves are kept
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id} [delete]
+// @Security     BearerAuth
+func (u *collectionUsecase) DeleteCollection(userId uint, id uint) error {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return err
+	}
+
+	err = u.collectionRepository.DeleteCollection(collection)
+	if err != nil {
+		return errors.New("Failed to delete collection")
+	}
+
+	return nil
+}
+
+// AddCollectionItem godoc
+// @Summary      Add an article to a collection
+// @Description  Append a published article at the end of a reading list, adding it again keeps its position
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Param        request body dtos.CollectionItemRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id}/items [post]
+// @Security     BearerAuth
+func (u *collectionUsecase) AddCollectionItem(userId uint, id uint, req dtos.CollectionItemRequest) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	_, err = u.articleUsecase.GetArticleByID(req.ArticleID)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Article not found")
+	}
+
+	err = u.collectionRepository.AddCollectionItem(models.CollectionItem{
+		CollectionID: collection.ID,
+		ArticleID:    req.ArticleID,
+	})
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to add article to collection")
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// RemoveCollectionItem godoc
+// @Summary      Remove an article from a collection
+// @Description  Remove an article from a reading list
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Param article_id path integer true "ID article"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id}/items/{article_id} [delete]
+// @Security     BearerAuth
+func (u *collectionUsecase) RemoveCollectionItem(userId uint, id uint, articleId uint) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	err = u.collectionRepository.DeleteCollectionItem(collection.ID, articleId)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to remove article from collection")
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// ReorderCollectionItems godoc
+// @Summary      Reorder a collection
+// @Description  Move the given articles to the top of a reading list in the given order
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Param        request body dtos.CollectionOrderRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id}/items/order [put]
+// @Security     BearerAuth
+func (u *collectionUsecase) ReorderCollectionItems(userId uint, id uint, req dtos.CollectionOrderRequest) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	items, err := u.collectionRepository.GetCollectionItems(collection.ID)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to get collection items")
+	}
+
+	inCollection := map[uint]bool{}
+	for _, item := range items {
+		inCollection[item.ArticleID] = true
+	}
+
+	// Requested articles first, the rest keep their relative order
+	var order []uint
+	placed := map[uint]bool{}
+	for _, articleId := range req.ArticleIDs {
+		if inCollection[articleId] && !placed[articleId] {
+			order = append(order, articleId)
+			placed[articleId] = true
+		}
+	}
+	for _, item := range items {
+		if !placed[item.ArticleID] {
+			order = append(order, item.ArticleID)
+		}
+	}
+
+	err = u.collectionRepository.ReorderCollectionItems(collection.ID, order)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to reorder collection")
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// ShareCollection godoc
+// @Summary      Share a collection
+// @Description  Create an unguessable public link to a reading list, sharing again keeps the same link
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id}/share [put]
+// @Security     BearerAuth
+func (u *collectionUsecase) ShareCollection(userId uint, id uint) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	if collection.ShareToken == nil {
+		token, err := helpers.GenerateRandomToken(16)
+		if err != nil {
+			return dtos.CollectionResponse{}, errors.New("Failed to generate share link")
+		}
+		collection.ShareToken = &token
+
+		collection, err = u.collectionRepository.UpdateCollection(collection)
+		if err != nil {
+			return dtos.CollectionResponse{}, errors.New("Failed to share collection")
+		}
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// UnshareCollection godoc
+// @Summary      Stop sharing a collection
+// @Description  Revoke the public link of a reading list, sharing it later creates a new link
+// @Tags         User - Collection
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID collection"
+// @Success      200 {object} dtos.CollectionStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/collections/{id}/share [delete]
+// @Security     BearerAuth
+func (u *collectionUsecase) UnshareCollection(userId uint, id uint) (dtos.CollectionResponse, error) {
+	collection, err := u.ownCollection(userId, id)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	collection.ShareToken = nil
+
+	collection, err = u.collectionRepository.UpdateCollection(collection)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to unshare collection")
+	}
+
+	return u.collectionDetail(collection)
+}
+
+// Get a collection owned by the user, other users' collections are reported as missing
+func (u *collectionUsecase) ownCollection(userId uint, id uint) (models.Collection, error) {
+	collection, err := u.collectionRepository.GetCollectionByID(id)
+	if err != nil || collection.UserID != userId {
+		return collection, errors.New("Collection not found")
+	}
+
+	return collection, nil
+}
+
+// Collection with its published articles in order
+func (u *collectionUsecase) collectionDetail(collection models.Collection) (dtos.CollectionResponse, error) {
+	items, err := u.collectionRepository.GetCollectionItems(collection.ID)
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to get collection items")
+	}
+
+	var articleIds []uint
+	for _, item := range items {
+		articleIds = append(articleIds, item.ArticleID)
+	}
+
+	articles, err := u.articleUsecase.GetArticlesByIDs(articleIds)
+	if err != nil {
+		return dtos.CollectionResponse{}, err
+	}
+
+	// Same count as the collection list
+	itemCounts, err := u.collectionRepository.CountCollectionItems([]uint{collection.ID})
+	if err != nil {
+		return dtos.CollectionResponse{}, errors.New("Failed to count collection items")
+	}
+
+	collectionResponse := newCollectionResponse(collection, u.shareURL)
+	collectionResponse.Articles = articles
+	collectionResponse.ItemCount = itemCounts[collection.ID]
+
+	return collectionResponse, nil
+}
+
+func newCollectionResponse(collection models.Collection, shareURL string) dtos.CollectionResponse {
+	collectionResponse := dtos.CollectionResponse{
+		CollectionID: collection.ID,
+		Name:         collection.Name,
+		Description:  collection.Description,
+		Shared:       collection.ShareToken != nil,
+		CreatedAt:    collection.CreatedAt,
+		UpdatedAt:    collection.UpdatedAt,
+	}
+
+	if collection.ShareToken != nil && shareURL != "" {
+		collectionResponse.ShareURL = shareURL + *collection.ShareToken
+	}
+
+	return collectionResponse
+}
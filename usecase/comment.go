@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"go_bedu/repositories"
+)
+
+type CommentUsecase interface {
+	GetArticleComments(articleId uint, page, limit int) ([]dtos.CommentResponse, int, error)
+	CreateComment(userId uint, articleId uint, req dtos.CommentRequest) (dtos.CommentResponse, error)
+	UpdateComment(userId uint, articleId uint, commentId uint, req dtos.UpdateCommentRequest) (dtos.CommentResponse, error)
+	DeleteComment(userId uint, articleId uint, commentId uint) error
+	GetModerationQueue(status string, page, limit int) ([]dtos.CommentResponse, int, error)
+	ModerateComment(commentId uint, status string) (dtos.CommentResponse, error)
+	AdminDeleteComment(commentId uint) error
+}
+
+type commentUsecase struct {
+	commentRepository repositories.CommentRepository
+	articleRepository repositories.ArticleRepository
+	userRepository    repositories.UserRepository
+}
+
+func NewCommentUsecase(commentRepository repositories.CommentRepository, articleRepository repositories.ArticleRepository, userRepository repositories.UserRepository) CommentUsecase {
+	return &commentUsecase{commentRepository, articleRepository, userRepository}
+}
+
+// GetArticleComments godoc
+// @Summary      Get comments of an article
+// @Description  Get approved comments with nested replies, paginated by top level comment
+// @Tags         Article - Comment
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCommentStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/comments [get]
+func (u *commentUsecase) GetArticleComments(articleId uint, page, limit int) ([]dtos.CommentResponse, int, error) {
+	article, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil || article.Status != models.ArticlePublished {
+		return nil, 0, errors.New("Article not found")
+	}
+
+	roots, count, err := u.commentRepository.GetRootComments(articleId, models.CommentApproved, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get comments")
+	}
+
+	replies, err := u.commentRepository.GetReplies(articleId, models.CommentApproved)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get replies")
+	}
+
+	// Group replies by parent so the tree is built without extra queries
+	repliesByParent := map[uint][]models.Comment{}
+	for _, reply := range replies {
+		repliesByParent[*reply.ParentID] = append(repliesByParent[*reply.ParentID], reply)
+	}
+
+	commentResponses := []dtos.CommentResponse{}
+	for _, root := range roots {
+		commentResponses = append(commentResponses, buildCommentTree(root, repliesByParent))
+	}
+
+	return commentResponses, count, nil
+}
+
+// CreateComment godoc
+// @Summary      Create a comment
+// @Description  Create a comment or reply on an article, the comment is visible after approved by admin
+// @Tags         Article - Comment
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param        request body dtos.CommentRequest true "Payload Body [RAW]"
+// @Success      201 {object} dtos.CommentCreatedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/comments [post]
+// @Security     BearerAuth
+func (u *commentUsecase) CreateComment(userId uint, articleId uint, req dtos.CommentRequest) (dtos.CommentResponse, error) {
+	var commentResponse dtos.CommentResponse
+
+	user, err := u.userRepository.GetUserById(userId)
+	if err != nil {
+		return commentResponse, errors.New("Failed to get user")
+	}
+
+	if !user.Verified {
+		return commentResponse, errors.New("Please verify your email first")
+	}
+
+	article, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil || article.Status != models.ArticlePublished {
+		return commentResponse, errors.New("Article not found")
+	}
+
+	if req.ParentID != nil {
+		parent, err := u.commentRepository.GetCommentByID(*req.ParentID)
+		if err != nil || parent.ArticleID != articleId {
+			return commentResponse, errors.New("Parent comment not found")
+		}
+	}
+
+	comment, err := u.commentRepository.CreateComment(models.Comment{
+		ArticleID: articleId,
+		UserID:    user.ID,
+		ParentID:  req.ParentID,
+		Body:      req.Body,
+		Status:    models.CommentPending,
+	})
+	if err != nil {
+		return commentResponse, errors.New("Failed to create comment")
+	}
+	comment.User = user
+
+	return newCommentResponse(comment), nil
+}
+
+// UpdateComment godoc
+// @Summary      Update a comment
+// @Description  Update own comment, the comment is moderated again after edited
+// @Tags         Article - Comment
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param comment_id path integer true "ID comment"
+// @Param        request body dtos.UpdateCommentRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.CommentStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/comments/{comment_id} [put]
+// @Security     BearerAuth
+func (u *commentUsecase) UpdateComment(userId uint, articleId uint, commentId uint, req dtos.UpdateCommentRequest) (dtos.CommentResponse, error) {
+	var commentResponse dtos.CommentResponse
+
+	comment, err := u.commentRepository.GetCommentByID(commentId)
+	if err != nil || comment.ArticleID != articleId {
+		return commentResponse, errors.New("Comment not found")
+	}
+
+	if comment.UserID != userId {
+		return commentResponse, errors.New("You can only edit your own comment")
+	}
+
+	comment.Body = req.Body
+	comment.Status = models.CommentPending
+
+	comment, err = u.commentRepository.UpdateComment(comment)
+	if err != nil {
+		return commentResponse, errors.New("Failed to update comment")
+	}
+
+	return newCommentResponse(comment), nil
+}
+
+// DeleteComment godoc
+// @Summary      Delete a comment
+// @Description  Delete own comment together with its replies
+// @Tags         Article - Comment
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param comment_id path integer true "ID comment"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/comments/{comment_id} [delete]
+// @Security     BearerAuth
+func (u *commentUsecase) DeleteComment(userId uint, articleId uint, commentId uint) error {
+	comment, err := u.commentRepository.GetCommentByID(commentId)
+	if err != nil || comment.ArticleID != articleId {
+		return errors.New("Comment not found")
+	}
+
+	if comment.UserID != userId {
+		return errors.New("You can only delete your own comment")
+	}
+
+	err = u.commentRepository.DeleteComment(comment)
+	if err != nil {
+		return errors.New("Failed to delete comment")
+	}
+
+	return nil
+}
+
+// GetModerationQueue godoc
+// @Summary      Get comment moderation queue
+// @Description  Get comments by status, pending comments are returned by default
+// @Tags         Admin - Comment
+// @Accept       json
+// @Produce      json
+// @Param status query string false "Pending, Approved or Hidden"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCommentStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/comments [get]
+// @Security     BearerAuth
+func (u *commentUsecase) GetModerationQueue(status string, page, limit int) ([]dtos.CommentResponse, int, error) {
+	if status == "" {
+		status = models.CommentPending
+	}
+
+	if !isCommentStatus(status) {
+		return nil, 0, errors.New("Comment status is not valid")
+	}
+
+	comments, count, err := u.commentRepository.GetCommentsByStatus(status, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get comments")
+	}
+
+	commentResponses := []dtos.CommentResponse{}
+	for _, comment := range comments {
+		commentResponse := newCommentResponse(comment)
+		commentResponse.ArticleTitle = comment.Article.Title
+		commentResponses = append(commentResponses, commentResponse)
+	}
+
+	return commentResponses, count, nil
+}
+
+// ModerateComment godoc
+// @Summary      Approve or hide a comment
+// @Description  Approve or hide a comment
+// @Tags         Admin - Comment
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID comment"
+// @Param action path string true "approve or hide"
+// @Success      200 {object} dtos.CommentStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/comments/{id}/{action} [put]
+// @Security     BearerAuth
+func (u *commentUsecase) ModerateComment(commentId uint, status string) (dtos.CommentResponse, error) {
+	var commentResponse dtos.CommentResponse
+
+	if !isCommentStatus(status) {
+		return commentResponse, errors.New("Comment status is not valid")
+	}
+
+	comment, err := u.commentRepository.GetCommentByID(commentId)
+	if err != nil {
+		return commentResponse, errors.New("Comment not found")
+	}
+
+	comment.Status = status
+
+	comment, err = u.commentRepository.UpdateComment(comment)
+	if err != nil {
+		return commentResponse, errors.New("Failed to moderate comment")
+	}
+
+	return newCommentResponse(comment), nil
+}
+
+// AdminDeleteComment godoc
+// @Summary      Delete a comment
+// @Description  Delete any comment together with its replies
+// @Tags         Admin - Comment
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID comment"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/comments/{id} [delete]
+// @Security     BearerAuth
+func (u *commentUsecase) AdminDeleteComment(commentId uint) error {
+	comment, err := u.commentRepository.GetCommentByID(commentId)
+	if err != nil {
+		return errors.New("Comment not found")
+	}
+
+	err = u.commentRepository.DeleteComment(comment)
+	if err != nil {
+		return errors.New("Failed to delete comment")
+	}
+
+	return nil
+}
+
+func isCommentStatus(status string) bool {
+	return status == models.CommentPending || status == models.CommentApproved || status == models.CommentHidden
+}
+
+// Attach replies recursively into the comment response
+func buildCommentTree(comment models.Comment, repliesByParent map[uint][]models.Comment) dtos.CommentResponse {
+	commentResponse := newCommentResponse(comment)
+
+	for _, reply := range repliesByParent[comment.ID] {
+		commentResponse.Replies = append(commentResponse.Replies, buildCommentTree(reply, repliesByParent))
+	}
+
+	return commentResponse
+}
+
+// Map Comment model into comment response
+func newCommentResponse(comment models.Comment) dtos.CommentResponse {
+	return dtos.CommentResponse{
+		CommentID:    comment.ID,
+		ArticleID:    comment.ArticleID,
+		ParentID:     comment.ParentID,
+		UserID:       comment.UserID,
+		Username:     comment.User.Username,
+		FullName:     comment.User.FullName,
+		PhotoProfile: comment.User.PhotoProfile,
+		Body:         comment.Body,
+		Status:       comment.Status,
+		Replies:      []dtos.CommentResponse{},
+		CreatedAt:    comment.CreatedAt,
+		UpdatedAt:    comment.UpdatedAt,
+	}
+}
@@ -0,0 +1,648 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/helpers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"log"
+	"strconv"
+	"time"
+)
+
+// Called once when an enrolled user completes every lesson of a course
+type CourseCompletedHandler func(userId uint, courseId uint, completedAt time.Time) error
+
+type CourseUsecase interface {
+	GetCourses(page, limit int) ([]dtos.CourseResponse, int, error)
+	GetCourse(userId uint, id uint) (dtos.CourseResponse, error)
+	GetUserCourses(userId uint, page, limit int) ([]dtos.CourseResponse, int, error)
+	EnrollCourse(userId uint, id uint) (dtos.CourseResponse, error)
+	UnenrollCourse(userId uint, id uint) error
+	GetAdminCourses(page, limit int) ([]dtos.CourseResponse, int, error)
+	GetAdminCourse(id uint) (dtos.CourseResponse, error)
+	CreateCourse(adminId uint, req dtos.CourseRequest) (dtos.CourseResponse, error)
+	UpdateCourse(id uint, req dtos.CourseRequest) (dtos.CourseResponse, error)
+	DeleteCourse(id uint) error
+	CompleteLessonByReading(userId uint, articleId uint, completedAt time.Time) error
+	CompleteLessonByQuiz(userId uint, articleId uint, score int, passedAt time.Time) error
+	OnCourseCompleted(handler CourseCompletedHandler)
+}
+
+type courseUsecase struct {
+	courseRepository  repositories.CourseRepository
+	articleRepository repositories.ArticleRepository
+	completedHandlers []CourseCompletedHandler
+}
+
+func NewCourseUsecase(courseRepository repositories.CourseRepository, articleRepository repositories.ArticleRepository) CourseUsecase {
+	return &courseUsecase{
+		courseRepository:  courseRepository,
+		articleRepository: articleRepository,
+	}
+}
+
+// GetCourses godoc
+// @Summary      Get all courses
+// @Description  Get published learning paths with their number of lessons
+// @Tags         Course
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /course [get]
+func (u *courseUsecase) GetCourses(page, limit int) ([]dtos.CourseResponse, int, error) {
+	courses, count, err := u.courseRepository.GetCourses(true, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get courses")
+	}
+
+	var courseResponses []dtos.CourseResponse
+	for _, course := range courses {
+		courseResponse := newCourseResponse(course, false)
+		courseResponse.Lessons = nil
+		courseResponses = append(courseResponses, courseResponse)
+	}
+
+	return courseResponses, count, nil
+}
+
+// GetCourse godoc
+// @Summary      Get course by ID
+// @Description  Get a published learning path with its lessons, enrolled users also get their progress
+// @Tags         Course
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID course"
+// @Success      200 {object} dtos.CourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /course/{id} [get]
+func (u *courseUsecase) GetCourse(userId uint, id uint) (dtos.CourseResponse, error) {
+	course, err := u.courseRepository.GetCourseByID(id)
+	if err != nil || !course.Published {
+		return dtos.CourseResponse{}, errors.New("Course not found")
+	}
+
+	courseResponse := newCourseResponse(course, false)
+	if userId == 0 {
+		return courseResponse, nil
+	}
+
+	enrollment, err := u.courseRepository.GetEnrollment(userId, course.ID)
+	if err != nil {
+		// Not enrolled, the course is shown without progress
+		return courseResponse, nil
+	}
+
+	return u.courseProgress(courseResponse, enrollment)
+}
+
+// GetUserCourses godoc
+// @Summary      Get enrolled courses
+// @Description  Get learning paths the logged in user is enrolled in with the progress percentage, unfinished courses first
+// @Tags         User - Course
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/courses [get]
+// @Security     BearerAuth
+func (u *courseUsecase) GetUserCourses(userId uint, page, limit int) ([]dtos.CourseResponse, int, error) {
+	enrollments, count, err := u.courseRepository.GetEnrollments(userId, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get courses")
+	}
+
+	var courseResponses []dtos.CourseResponse
+	for _, enrollment := range enrollments {
+		courseResponse, err := u.courseProgress(newCourseResponse(enrollment.Course, false), enrollment)
+		if err != nil {
+			return nil, 0, err
+		}
+		courseResponse.Lessons = nil
+		courseResponses = append(courseResponses, courseResponse)
+	}
+
+	return courseResponses, count, nil
+}
+
+// EnrollCourse godoc
+// @Summary      Enroll in a course
+// @Description  Enroll the logged in user in a published course, articles already read or quizzes already passed count as completed lessons
+// @Tags         Course
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID course"
+// @Success      200 {object} dtos.CourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /course/{id}/enroll [put]
+// @Security     BearerAuth
+func (u *courseUsecase) EnrollCourse(userId uint, id uint) (dtos.CourseResponse, error) {
+	course, err := u.courseRepository.GetCourseByID(id)
+	if err != nil || !course.Published {
+		return dtos.CourseResponse{}, errors.New("Course not found")
+	}
+
+	enrollment, err := u.courseRepository.CreateEnrollment(models.CourseEnrollment{
+		UserID:   userId,
+		CourseID: course.ID,
+	})
+	if err != nil {
+		return dtos.CourseResponse{}, errors.New("Failed to enroll course")
+	}
+	enrollment.Course = course
+
+	err = u.syncEnrollment(enrollment)
+	if err != nil {
+		return dtos.CourseResponse{}, err
+	}
+
+	// Reload to get the completion set by the sync
+	enrollment, err = u.courseRepository.GetEnrollment(userId, course.ID)
+	if err != nil {
+		return dtos.CourseResponse{}, errors.New("Failed to get enrollment")
+	}
+
+	return u.courseProgress(newCourseResponse(course, false), enrollment)
+}
+
+// UnenrollCourse godoc
+// @Summary      Leave a course
+// @Description  Remove the enrollment and lesson progress of the logged in user, reading history is kept
+// @Tags         Course
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID course"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /course/{id}/enroll [delete]
+// @Security     BearerAuth
+func (u *courseUsecase) UnenrollCourse(userId uint, id uint) error {
+	err := u.courseRepository.DeleteEnrollment(userId, id)
+	if err != nil {
+		return errors.New("Failed to leave course")
+	}
+
+	return nil
+}
+
+// GetAdminCourses godoc
+// @Summary      Get all courses for admin
+// @Description  Get learning paths including unpublished ones
+// @Tags         Admin - Course
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllCourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/courses [get]
+// @Security BearerAuth
+func (u *courseUsecase) GetAdminCourses(page, limit int) ([]dtos.CourseResponse, int, error) {
+	courses, count, err := u.courseRepository.GetCourses(false, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get courses")
+	}
+
+	var courseResponses []dtos.CourseResponse
+	for _, course := range courses {
+		courseResponses = append(courseResponses, newCourseResponse(course, true))
+	}
+
+	return courseResponses, count, nil
+}
+
+// GetAdminCourse godoc
+// @Summary      Get course by ID for admin
+// @Description  Get a learning path with every lesson including unpublished articles
+// @Tags         Admin - Course
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID course"
+// @Success      200 {object} dtos.CourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/courses/{id} [get]
+// @Security BearerAuth
+func (u *courseUsecase) GetAdminCourse(id uint) (dtos.CourseResponse, error) {
+	course, err := u.courseRepository.GetCourseByID(id)
+	if err != nil {
+		return dtos.CourseResponse{}, errors.New("Course not found")
+	}
+
+	return newCourseResponse(course, true), nil
+}
+
+// CreateCourse godoc
+// @Summary      Create a course
+// @Description  Create a learning path from ordered articles
+// @Tags         Admin - Course
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.CourseRequest true "Payload Body [RAW]"
+// @Success      201 {object} dtos.CourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/courses [post]
+// @Security BearerAuth
+func (u *courseUsecase) CreateCourse(adminId uint, req dtos.CourseRequest) (dtos.CourseResponse, error) {
+	lessons, err := u.courseLessons(req.Lessons)
+	if err != nil {
+		return dtos.CourseResponse{}, err
+	}
+
+	slug, err := u.uniqueCourseSlug(req.Title, 0)
+	if err != nil {
+		return dtos.CourseResponse{}, err
+	}
+
+	course, err := u.courseRepository.CreateCourse(models.Course{
+		AdministratorID: adminId,
+		Title:           req.Title,
+		Slug:            slug,
+		Description:     req.Description,
+		Thumbnail:       req.Thumbnail,
+		Published:       req.Published,
+		Lessons:         lessons,
+	})
+	if err != nil {
+		return dtos.CourseResponse{}, errors.New("Failed to create course")
+	}
+
+	return newCourseResponse(course, true), nil
+}
+
+// UpdateCourse godoc
+// @Summary      Update a course
+// @Description  Update a learning path and replace its lessons, progress on remaining lessons is kept
+// @Tags         Admin - Course
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID course"
+// @Param        request body dtos.CourseRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.CourseStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/courses/{id} [put]
+// @Security BearerAuth
+func (u *courseUsecase) UpdateCourse(id uint, req dtos.CourseRequest) (dtos.CourseResponse, error) {
+	course, err := u.courseRepository.GetCourseByID(id)
+	if err != nil {
+		return dtos.CourseResponse{}, errors.New("Course not found")
+	}
+
+	lessons, err := u.courseLessons(req.Lessons)
+	if err != nil {
+		return dtos.CourseResponse{}, err
+	}
+
+	if req.Title != course.Title {
+		course.Slug, err = u.uniqueCourseSlug(req.Title, course.ID)
+		if err != nil {
+			return dtos.CourseResponse{}, err
+		}
+	}
+
+	course.Title = req.Title
+	course.Description = req.Description
+	course.Thumbnail = req.Thumbnail
+	course.Published = req.Published
+	course.Lessons = lessons
+
+	course, err = u.courseRepository.UpdateCourse(course)
+	if err != nil {
+		return dtos.CourseResponse{}, errors.New("Failed to update course")
+	}
+
+	return newCourseResponse(course, true), nil
+}
+
+// DeleteCourse godoc
+// @Summary      Delete a course
+// @Description  Delete a learning path, the articles are kept
+// @Tags         Admin - Course
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID course"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/courses/{id} [delete]
+// @Security BearerAuth
+func (u *courseUsecase) DeleteCourse(id uint) error {
+	course, err := u.courseRepository.GetCourseByID(id)
+	if err != nil {
+		return errors.New("Course not found")
+	}
+
+	err = u.courseRepository.DeleteCourse(course)
+	if err != nil {
+		return errors.New("Failed to delete course")
+	}
+
+	return nil
+}
+
+// Reading completion handler, completes lessons of the article in enrolled courses
+func (u *courseUsecase) CompleteLessonByReading(userId uint, articleId uint, completedAt time.Time) error {
+	return u.syncArticleEnrollments(userId, articleId)
+}
+
+// Quiz pass handler, completes lessons of the article in enrolled courses
+func (u *courseUsecase) CompleteLessonByQuiz(userId uint, articleId uint, score int, passedAt time.Time) error {
+	return u.syncArticleEnrollments(userId, articleId)
+}
+
+// Register a handler for course completions, handlers are registered while wiring routes
+func (u *courseUsecase) OnCourseCompleted(handler CourseCompletedHandler) {
+	u.completedHandlers = append(u.completedHandlers, handler)
+}
+
+func (u *courseUsecase) syncArticleEnrollments(userId uint, articleId uint) error {
+	enrollments, err := u.courseRepository.GetEnrollmentsByArticle(userId, articleId)
+	if err != nil {
+		return err
+	}
+
+	for _, enrollment := range enrollments {
+		err = u.syncEnrollment(enrollment)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Save lessons completed by reading or quiz pass and complete the course once every lesson is done
+func (u *courseUsecase) syncEnrollment(enrollment models.CourseEnrollment) error {
+	lessons := visibleLessons(enrollment.Course, false)
+
+	var articleIds []uint
+	for _, lesson := range lessons {
+		articleIds = append(articleIds, lesson.ArticleID)
+	}
+
+	read, err := u.courseRepository.GetCompletedReadings(enrollment.UserID, articleIds)
+	if err != nil {
+		return errors.New("Failed to get reading history")
+	}
+
+	passed, err := u.courseRepository.GetPassedQuizzes(enrollment.UserID, articleIds)
+	if err != nil {
+		return errors.New("Failed to get quiz attempts")
+	}
+
+	var completions []models.CourseLessonCompletion
+	for _, lesson := range lessons {
+		completedAt, ok := lessonCompletedAt(lesson, read, passed)
+		if !ok {
+			continue
+		}
+		completions = append(completions, models.CourseLessonCompletion{
+			UserID:      enrollment.UserID,
+			CourseID:    enrollment.CourseID,
+			ArticleID:   lesson.ArticleID,
+			CompletedAt: completedAt,
+		})
+	}
+
+	err = u.courseRepository.SaveLessonCompletions(completions)
+	if err != nil {
+		return errors.New("Failed to save lesson progress")
+	}
+
+	if len(lessons) == 0 || enrollment.CompletedAt != nil {
+		return nil
+	}
+
+	// Lessons completed earlier stay completed even when no longer derived from reading or quiz
+	saved, err := u.courseRepository.GetLessonCompletions(enrollment.UserID, []uint{enrollment.CourseID})
+	if err != nil {
+		return errors.New("Failed to get lesson progress")
+	}
+
+	var lastCompletedAt time.Time
+	completedAt := map[uint]time.Time{}
+	for _, completion := range saved {
+		completedAt[completion.ArticleID] = completion.CompletedAt
+	}
+
+	for _, lesson := range lessons {
+		at, ok := completedAt[lesson.ArticleID]
+		if !ok {
+			return nil
+		}
+		if at.After(lastCompletedAt) {
+			lastCompletedAt = at
+		}
+	}
+
+	// Backfilled lessons may be older than the enrollment, the course is completed when enrolled then
+	if lastCompletedAt.Before(enrollment.CreatedAt) {
+		lastCompletedAt = enrollment.CreatedAt
+	}
+
+	completed, err := u.courseRepository.MarkEnrollmentCompleted(enrollment, lastCompletedAt)
+	if err != nil {
+		return errors.New("Failed to complete course")
+	}
+	if completed {
+		u.notifyCourseCompleted(enrollment.UserID, enrollment.CourseID, lastCompletedAt)
+	}
+
+	return nil
+}
+
+// Course completion is already saved, a failing handler is logged
+func (u *courseUsecase) notifyCourseCompleted(userId uint, courseId uint, completedAt time.Time) {
+	for _, handler := range u.completedHandlers {
+		err := handler(userId, courseId, completedAt)
+		if err != nil {
+			log.Printf("course: completed handler: user %d course %d: %v", userId, courseId, err)
+		}
+	}
+}
+
+// Fill lesson completion and progress of an enrolled user
+func (u *courseUsecase) courseProgress(courseResponse dtos.CourseResponse, enrollment models.CourseEnrollment) (dtos.CourseResponse, error) {
+	completions, err := u.courseRepository.GetLessonCompletions(enrollment.UserID, []uint{enrollment.CourseID})
+	if err != nil {
+		return courseResponse, errors.New("Failed to get lesson progress")
+	}
+
+	completedAt := map[uint]time.Time{}
+	for _, completion := range completions {
+		completedAt[completion.ArticleID] = completion.CompletedAt
+	}
+
+	progress := &dtos.CourseProgressResponse{
+		TotalLessons: len(courseResponse.Lessons),
+		EnrolledAt:   enrollment.CreatedAt,
+		CompletedAt:  enrollment.CompletedAt,
+	}
+
+	for i, lesson := range courseResponse.Lessons {
+		if at, ok := completedAt[lesson.ArticleID]; ok {
+			courseResponse.Lessons[i].Completed = true
+			courseResponse.Lessons[i].CompletedAt = &at
+			progress.CompletedLessons++
+		} else if progress.NextArticleID == 0 {
+			progress.NextArticleID = lesson.ArticleID
+		}
+	}
+
+	if progress.TotalLessons > 0 {
+		progress.Percentage = progress.CompletedLessons * 100 / progress.TotalLessons
+	}
+	courseResponse.Progress = progress
+
+	return courseResponse, nil
+}
+
+// Build lessons from the request, every article must exist and appear once
+func (u *courseUsecase) courseLessons(lessonRequests []dtos.CourseLessonRequest) ([]models.CourseLesson, error) {
+	var lessons []models.CourseLesson
+
+	seen := map[uint]bool{}
+	for i, lessonRequest := range lessonRequests {
+		if seen[lessonRequest.ArticleID] {
+			return nil, errors.New("Article can only be one lesson of the course")
+		}
+		seen[lessonRequest.ArticleID] = true
+
+		_, err := u.articleRepository.GetArticleByID(lessonRequest.ArticleID)
+		if err != nil {
+			return nil, errors.New("Article " + strconv.Itoa(int(lessonRequest.ArticleID)) + " not found")
+		}
+
+		lessons = append(lessons, models.CourseLesson{
+			ArticleID:   lessonRequest.ArticleID,
+			Position:    i + 1,
+			RequireQuiz: lessonRequest.RequireQuiz,
+		})
+	}
+
+	return lessons, nil
+}
+
+func (u *courseUsecase) uniqueCourseSlug(title string, courseID uint) (string, error) {
+	base := helpers.CreateSlug(title)
+	if base == "" {
+		base = "course"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := u.courseRepository.IsCourseSlugTaken(slug, courseID)
+		if err != nil {
+			return "", errors.New("Failed to check course slug")
+		}
+		if !taken {
+			return slug, nil
+		}
+
+		slug = base + "-" + strconv.Itoa(i)
+	}
+}
+
+// Lessons shown to users skip deleted and unpublished articles, admins see every lesson
+func visibleLessons(course models.Course, admin bool) []models.CourseLesson {
+	var lessons []models.CourseLesson
+	for _, lesson := range course.Lessons {
+		if lesson.Article.ID == 0 {
+			continue
+		}
+		if !admin && lesson.Article.Status != models.ArticlePublished {
+			continue
+		}
+		lessons = append(lessons, lesson)
+	}
+
+	return lessons
+}
+
+// A passed quiz always completes the lesson, reading completes it unless the quiz is required
+func lessonCompletedAt(lesson models.CourseLesson, read, passed map[uint]time.Time) (time.Time, bool) {
+	passedAt, hasPassed := passed[lesson.ArticleID]
+	readAt, hasRead := read[lesson.ArticleID]
+
+	switch {
+	case hasPassed && (!hasRead || lesson.RequireQuiz || passedAt.Before(readAt)):
+		return passedAt, true
+	case hasRead && !lesson.RequireQuiz:
+		return readAt, true
+	}
+
+	return time.Time{}, false
+}
+
+func newCourseResponse(course models.Course, admin bool) dtos.CourseResponse {
+	courseResponse := dtos.CourseResponse{
+		CourseID:    course.ID,
+		Title:       course.Title,
+		Slug:        course.Slug,
+		Description: course.Description,
+		Thumbnail:   course.Thumbnail,
+		Published:   course.Published,
+		Lessons:     []dtos.CourseLessonResponse{},
+		CreatedAt:   course.CreatedAt,
+		UpdatedAt:   course.UpdatedAt,
+	}
+
+	for _, lesson := range visibleLessons(course, admin) {
+		lessonResponse := dtos.CourseLessonResponse{
+			Position:    len(courseResponse.Lessons) + 1,
+			ArticleID:   lesson.ArticleID,
+			Title:       lesson.Article.Title,
+			Slug:        lesson.Article.Slug,
+			Thumbnail:   lesson.Article.Thumbnail,
+			ReadingTime: lesson.Article.ReadingTime,
+			RequireQuiz: lesson.RequireQuiz,
+		}
+		if admin {
+			lessonResponse.Status = lesson.Article.Status
+		}
+		courseResponse.Lessons = append(courseResponse.Lessons, lessonResponse)
+	}
+	courseResponse.LessonCount = len(courseResponse.Lessons)
+
+	return courseResponse
+}
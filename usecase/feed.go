@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/initializers"
+	"go_bedu/repositories"
+	"mime"
+	"net/url"
+	"path"
+	"time"
+)
+
+type FeedUsecase interface {
+	GetRSSFeed(filter dtos.ArticleFilter, selfURL string) (dtos.FeedResponse, error)
+	GetAtomFeed(filter dtos.ArticleFilter, selfURL string) (dtos.FeedResponse, error)
+}
+
+type feedUsecase struct {
+	articleUsecase     ArticleUsecase
+	categoryRepository repositories.CategoryRepository
+	tagRepository      repositories.TagRepository
+	itemLimit          int
+}
+
+func NewFeedUsecase(articleUsecase ArticleUsecase, categoryRepository repositories.CategoryRepository, tagRepository repositories.TagRepository, itemLimit int) FeedUsecase {
+	return &feedUsecase{articleUsecase, categoryRepository, tagRepository, itemLimit}
+}
+
+// Articles and channel information shared by RSS and Atom
+type feedSource struct {
+	title        string
+	description  string
+	link         string
+	clientOrigin string
+	articles     []dtos.ArticleDetailResponse
+	lastModified time.Time
+}
+
+// GetRSSFeed godoc
+// @Summary      RSS feed
+// @Description  RSS 2.0 feed of latest published articles, optionally by category or tag. Supports ETag and Last-Modified
+// @Tags         Feed
+// @Produce      xml
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag slug"
+// @Success      200 {object} dtos.RSSFeed
+// @Success      304
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /feed.rss [get]
+func (u *feedUsecase) GetRSSFeed(filter dtos.ArticleFilter, selfURL string) (dtos.FeedResponse, error) {
+	source, err := u.feedSource(filter)
+	if err != nil {
+		return dtos.FeedResponse{}, err
+	}
+
+	feed := dtos.RSSFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: dtos.RSSChannel{
+			Title:       source.title,
+			Link:        source.link,
+			Description: source.description,
+			Language:    "id",
+			AtomLink:    dtos.RSSLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       []dtos.RSSItem{},
+		},
+	}
+	if !source.lastModified.IsZero() {
+		feed.Channel.LastBuildDate = source.lastModified.Format(time.RFC1123Z)
+	}
+
+	for _, article := range source.articles {
+		link := articleLink(source.clientOrigin, article.Slug)
+		item := dtos.RSSItem{
+			Title:       article.Title,
+			Link:        link,
+			Description: article.Abstract,
+			GUID:        dtos.RSSGUID{IsPermaLink: true, Value: link},
+			Categories:  feedCategories(article),
+		}
+		if article.PublishedAt != nil {
+			item.PubDate = article.PublishedAt.Format(time.RFC1123Z)
+		}
+		if article.Thumbnail != "" {
+			item.Enclosure = &dtos.RSSEnclosure{URL: article.Thumbnail, Type: imageType(article.Thumbnail)}
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return renderFeed(feed, "application/rss+xml; charset=utf-8", source.lastModified)
+}
+
+// GetAtomFeed godoc
+// @Summary      Atom feed
+// @Description  Atom feed of latest published articles, optionally by category or tag. Supports ETag and Last-Modified
+// @Tags         Feed
+// @Produce      xml
+// @Param category query string false "Category slug"
+// @Param tag query string false "Tag slug"
+// @Success      200 {object} dtos.AtomFeed
+// @Success      304
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /feed.atom [get]
+func (u *feedUsecase) GetAtomFeed(filter dtos.ArticleFilter, selfURL string) (dtos.FeedResponse, error) {
+	source, err := u.feedSource(filter)
+	if err != nil {
+		return dtos.FeedResponse{}, err
+	}
+
+	feed := dtos.AtomFeed{
+		ID:      selfURL,
+		Title:   source.title,
+		Updated: source.lastModified.UTC().Format(time.RFC3339),
+		Links: []dtos.AtomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: source.link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: []dtos.AtomEntry{},
+	}
+
+	for _, article := range source.articles {
+		link := articleLink(source.clientOrigin, article.Slug)
+		entry := dtos.AtomEntry{
+			ID:      link,
+			Title:   article.Title,
+			Updated: article.UpdatedAt.UTC().Format(time.RFC3339),
+			Summary: article.Abstract,
+			Links:   []dtos.AtomLink{{Href: link, Rel: "alternate", Type: "text/html"}},
+		}
+		if article.PublishedAt != nil {
+			entry.Published = article.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		if article.Thumbnail != "" {
+			entry.Links = append(entry.Links, dtos.AtomLink{Href: article.Thumbnail, Rel: "enclosure", Type: imageType(article.Thumbnail)})
+		}
+		for _, category := range feedCategories(article) {
+			entry.Categories = append(entry.Categories, dtos.AtomCategory{Term: category})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return renderFeed(feed, "application/atom+xml; charset=utf-8", source.lastModified)
+}
+
+// Get latest published articles of the feed and describe the channel
+func (u *feedUsecase) feedSource(filter dtos.ArticleFilter) (feedSource, error) {
+	config, err := initializers.LoadConfig(".")
+	if err != nil {
+		return feedSource{}, errors.New("Failed to load config")
+	}
+
+	source := feedSource{
+		title:        "bEDU",
+		description:  "Artikel terbaru dari bEDU",
+		link:         config.ClientOrigin,
+		clientOrigin: config.ClientOrigin,
+	}
+
+	if filter.Category != "" {
+		category, err := u.categoryRepository.GetCategoryBySlug(filter.Category)
+		if err != nil {
+			return source, errors.New("Category not found")
+		}
+		source.title += " - " + category.Name
+		source.description = "Artikel terbaru kategori " + category.Name + " dari bEDU"
+	}
+
+	if filter.Tag != "" {
+		tag, err := u.tagRepository.GetTagBySlug(filter.Tag)
+		if err != nil {
+			return source, errors.New("Tag not found")
+		}
+		source.title += " - #" + tag.Name
+		source.description = "Artikel terbaru dengan tag " + tag.Name + " dari bEDU"
+	}
+
+	source.articles, _, err = u.articleUsecase.GetAllArticles(filter, 1, u.itemLimit)
+	if err != nil {
+		return source, errors.New("Failed to get articles")
+	}
+
+	for _, article := range source.articles {
+		if article.UpdatedAt.After(source.lastModified) {
+			source.lastModified = article.UpdatedAt
+		}
+	}
+
+	return source, nil
+}
+
+// Marshal feed document, the ETag is the hash of the rendered body
+func renderFeed(feed interface{}, contentType string, lastModified time.Time) (dtos.FeedResponse, error) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return dtos.FeedResponse{}, errors.New("Failed to render feed")
+	}
+	body = append([]byte(xml.Header), body...)
+
+	hash := sha1.Sum(body)
+
+	return dtos.FeedResponse{
+		Body:         body,
+		ContentType:  contentType,
+		ETag:         `"` + hex.EncodeToString(hash[:]) + `"`,
+		LastModified: lastModified,
+	}, nil
+}
+
+func articleLink(clientOrigin string, slug string) string {
+	return clientOrigin + "/#/article/" + url.PathEscape(slug)
+}
+
+func feedCategories(article dtos.ArticleDetailResponse) []string {
+	var categories []string
+	if article.Category != nil {
+		categories = append(categories, article.Category.Name)
+	}
+	for _, tag := range article.Tags {
+		categories = append(categories, tag.Name)
+	}
+
+	return categories
+}
+
+// Guess enclosure MIME type from the image extension
+func imageType(image string) string {
+	imagePath := image
+	if parsed, err := url.Parse(image); err == nil {
+		imagePath = parsed.Path
+	}
+
+	if imageType := mime.TypeByExtension(path.Ext(imagePath)); imageType != "" {
+		return imageType
+	}
+
+	return "image/jpeg"
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"go_bedu/repositories"
+)
+
+type FollowUsecase interface {
+	FollowAuthor(userId uint, username string) (dtos.FollowResponse, error)
+	UnfollowAuthor(userId uint, username string) (dtos.FollowResponse, error)
+	FollowCategory(userId uint, slug string) (dtos.FollowResponse, error)
+	UnfollowCategory(userId uint, slug string) (dtos.FollowResponse, error)
+	GetFollowing(userId uint) (dtos.FollowingResponse, error)
+	NotifyFollowers(article models.Article) error
+}
+
+type followUsecase struct {
+	followRepository       repositories.FollowRepository
+	notificationRepository repositories.NotificationRepository
+	adminRepository        repositories.AdminRepository
+	categoryRepository     repositories.CategoryRepository
+}
+
+func NewFollowUsecase(followRepository repositories.FollowRepository, notificationRepository repositories.NotificationRepository, adminRepository repositories.AdminRepository, categoryRepository repositories.CategoryRepository) FollowUsecase {
+	return &followUsecase{followRepository, notificationRepository, adminRepository, categoryRepository}
+}
+
+// FollowAuthor godoc
+// @Summary      Follow an author
+// @Description  Follow an administrator to get notified of new articles, following again keeps a single follow
+// @Tags         Author
+// @Accept       json
+// @Produce      json
+// @Param username path string true "Username of the author"
+// @Success      200 {object} dtos.FollowStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /author/{username}/follow [put]
+// @Security     BearerAuth
+func (u *followUsecase) FollowAuthor(userId uint, username string) (dtos.FollowResponse, error) {
+	admin, err := u.adminRepository.GetAdminByUsername(username)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Author not found")
+	}
+
+	err = u.followRepository.FollowAuthor(userId, admin.ID)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to follow author")
+	}
+
+	return u.authorFollowResponse(userId, admin.ID)
+}
+
+// UnfollowAuthor godoc
+// @Summary      Unfollow an author
+// @Description  Stop following an administrator, unfollowing an author not followed succeeds
+// @Tags         Author
+// @Accept       json
+// @Produce      json
+// @Param username path string true "Username of the author"
+// @Success      200 {object} dtos.FollowStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /author/{username}/follow [delete]
+// @Security     BearerAuth
+func (u *followUsecase) UnfollowAuthor(userId uint, username string) (dtos.FollowResponse, error) {
+	admin, err := u.adminRepository.GetAdminByUsername(username)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Author not found")
+	}
+
+	err = u.followRepository.UnfollowAuthor(userId, admin.ID)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to unfollow author")
+	}
+
+	return u.authorFollowResponse(userId, admin.ID)
+}
+
+// FollowCategory godoc
+// @Summary      Follow a category
+// @Description  Follow a category to get notified of new articles, following again keeps a single follow
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param slug path string true "Category slug"
+// @Success      200 {object} dtos.FollowStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /category/{slug}/follow [put]
+// @Security     BearerAuth
+func (u *followUsecase) FollowCategory(userId uint, slug string) (dtos.FollowResponse, error) {
+	category, err := u.categoryRepository.GetCategoryBySlug(slug)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Category not found")
+	}
+
+	err = u.followRepository.FollowCategory(userId, category.ID)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to follow category")
+	}
+
+	return u.categoryFollowResponse(userId, category.ID)
+}
+
+// UnfollowCategory godoc
+// @Summary      Unfollow a category
+// @Description  Stop following a category, unfollowing a category not followed succeeds
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param slug path string true "Category slug"
+// @Success      200 {object} dtos.FollowStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /category/{slug}/follow [delete]
+// @Security     BearerAuth
+func (u *followUsecase) UnfollowCategory(userId uint, slug string) (dtos.FollowResponse, error) {
+	category, err := u.categoryRepository.GetCategoryBySlug(slug)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Category not found")
+	}
+
+	err = u.followRepository.UnfollowCategory(userId, category.ID)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to unfollow category")
+	}
+
+	return u.categoryFollowResponse(userId, category.ID)
+}
+
+// GetFollowing godoc
+// @Summary      Get following
+// @Description  Get authors and categories followed by the logged in user, latest followed first
+// @Tags         User - Account
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.FollowingStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/following [get]
+// @Security     BearerAuth
+func (u *followUsecase) GetFollowing(userId uint) (dtos.FollowingResponse, error) {
+	following := dtos.FollowingResponse{
+		Authors:    []dtos.FollowedAuthorResponse{},
+		Categories: []dtos.FollowedCategoryResponse{},
+	}
+
+	authors, err := u.followRepository.GetFollowedAuthors(userId)
+	if err != nil {
+		return following, errors.New("Failed to get followed authors")
+	}
+
+	categories, err := u.followRepository.GetFollowedCategories(userId)
+	if err != nil {
+		return following, errors.New("Failed to get followed categories")
+	}
+
+	var adminIds, categoryIds []uint
+	for _, author := range authors {
+		adminIds = append(adminIds, author.AdministratorID)
+	}
+	for _, category := range categories {
+		categoryIds = append(categoryIds, category.CategoryID)
+	}
+
+	authorFollowers, err := u.followRepository.CountAuthorFollowers(adminIds)
+	if err != nil {
+		return following, errors.New("Failed to count followers")
+	}
+
+	categoryFollowers, err := u.followRepository.CountCategoryFollowers(categoryIds)
+	if err != nil {
+		return following, errors.New("Failed to count followers")
+	}
+
+	for _, author := range authors {
+		following.Authors = append(following.Authors, dtos.FollowedAuthorResponse{
+			Username:      author.Administrator.Username,
+			Name:          author.Administrator.Nama,
+			PhotoProfile:  author.Administrator.PhotoProfile,
+			FollowerCount: authorFollowers[author.AdministratorID],
+			FollowedAt:    author.CreatedAt,
+		})
+	}
+
+	for _, category := range categories {
+		following.Categories = append(following.Categories, dtos.FollowedCategoryResponse{
+			CategoryID:    category.CategoryID,
+			Name:          category.Category.Name,
+			Slug:          category.Category.Slug,
+			Icon:          category.Category.Icon,
+			FollowerCount: categoryFollowers[category.CategoryID],
+			FollowedAt:    category.CreatedAt,
+		})
+	}
+
+	return following, nil
+}
+
+// Article published handler, notifies followers of the author first then followers of the category
+func (u *followUsecase) NotifyFollowers(article models.Article) error {
+	var notifications []models.Notification
+	notified := map[uint]bool{}
+
+	addNotifications := func(userIds []uint, message string) {
+		for _, userId := range userIds {
+			if notified[userId] {
+				continue
+			}
+			notified[userId] = true
+			notifications = append(notifications, models.Notification{
+				UserID:    userId,
+				Type:      models.NotificationNewArticle,
+				ArticleID: article.ID,
+				Message:   message,
+			})
+		}
+	}
+
+	admin, err := u.adminRepository.GetAdminById(article.AdministratorID)
+	if err == nil {
+		userIds, err := u.followRepository.GetAuthorFollowerIDs(admin.ID)
+		if err != nil {
+			return errors.New("Failed to get author followers")
+		}
+		addNotifications(userIds, "New article from "+admin.Nama+": "+article.Title)
+	}
+
+	if article.CategoryID != nil {
+		category, err := u.categoryRepository.GetCategoryByID(*article.CategoryID)
+		if err == nil {
+			userIds, err := u.followRepository.GetCategoryFollowerIDs(category.ID)
+			if err != nil {
+				return errors.New("Failed to get category followers")
+			}
+			addNotifications(userIds, "New article in "+category.Name+": "+article.Title)
+		}
+	}
+
+	err = u.notificationRepository.CreateNotifications(notifications)
+	if err != nil {
+		return errors.New("Failed to create notifications")
+	}
+
+	return nil
+}
+
+func (u *followUsecase) authorFollowResponse(userId uint, adminId uint) (dtos.FollowResponse, error) {
+	following, err := u.followRepository.IsFollowingAuthor(userId, adminId)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to get follow")
+	}
+
+	followers, err := u.followRepository.CountAuthorFollowers([]uint{adminId})
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to count followers")
+	}
+
+	return dtos.FollowResponse{Following: following, FollowerCount: followers[adminId]}, nil
+}
+
+func (u *followUsecase) categoryFollowResponse(userId uint, categoryId uint) (dtos.FollowResponse, error) {
+	following, err := u.followRepository.IsFollowingCategory(userId, categoryId)
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to get follow")
+	}
+
+	followers, err := u.followRepository.CountCategoryFollowers([]uint{categoryId})
+	if err != nil {
+		return dtos.FollowResponse{}, errors.New("Failed to count followers")
+	}
+
+	return dtos.FollowResponse{Following: following, FollowerCount: followers[categoryId]}, nil
+}
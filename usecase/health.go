@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"go_bedu/dtos"
+	"go_bedu/repositories"
+	"log"
+)
+
+type HealthUsecase interface {
+	GetHealth() (dtos.HealthResponse, bool)
+}
+
+type healthUsecase struct {
+	healthRepository repositories.HealthRepository
+	publishScheduler PublishScheduler
+}
+
+func NewHealthUsecase(healthRepository repositories.HealthRepository, publishScheduler PublishScheduler) HealthUsecase {
+	return &healthUsecase{healthRepository, publishScheduler}
+}
+
+// GetHealth godoc
+// @Summary      Health check
+// @Description  Get database and publish scheduler state
+// @Tags         Health
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.HealthStatusOKResponse
+// @Failure      503 {object} dtos.HealthStatusOKResponse
+// @Router       /health [get]
+func (u *healthUsecase) GetHealth() (dtos.HealthResponse, bool) {
+	healthy := true
+	health := dtos.HealthResponse{
+		Status:    "ok",
+		Database:  "ok",
+		Scheduler: u.publishScheduler.Status(),
+	}
+
+	// Endpoint is public, errors can contain host and user so they are only logged
+	if err := u.healthRepository.Ping(); err != nil {
+		log.Printf("health: database: %v", err)
+		healthy = false
+		health.Status = "unavailable"
+		health.Database = "down"
+	}
+
+	if health.Scheduler.LastError != "" {
+		health.Scheduler.LastError = "Failed to publish scheduled articles"
+	}
+
+	if !health.Scheduler.Running {
+		healthy = false
+		health.Status = "unavailable"
+	}
+
+	return health, healthy
+}
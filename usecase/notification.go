@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/repositories"
+	"time"
+)
+
+type NotificationUsecase interface {
+	GetNotifications(userId uint, status string, page, limit int) ([]dtos.NotificationResponse, int, error)
+	MarkNotificationRead(userId uint, id uint) error
+	MarkAllNotificationsRead(userId uint) error
+}
+
+type notificationUsecase struct {
+	notificationRepository repositories.NotificationRepository
+}
+
+func NewNotificationUsecase(notificationRepository repositories.NotificationRepository) NotificationUsecase {
+	return &notificationUsecase{notificationRepository}
+}
+
+// GetNotifications godoc
+// @Summary      Get notifications
+// @Description  Get notifications of the logged in user, latest first
+// @Tags         User - Notification
+// @Accept       json
+// @Produce      json
+// @Param status query string false "unread to get unread notifications only"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllNotificationStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/notifications [get]
+// @Security     BearerAuth
+func (u *notificationUsecase) GetNotifications(userId uint, status string, page, limit int) ([]dtos.NotificationResponse, int, error) {
+	if status != "" && status != "unread" {
+		return nil, 0, errors.New("Status must be unread")
+	}
+
+	notifications, count, err := u.notificationRepository.GetNotifications(userId, status == "unread", page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get notifications")
+	}
+
+	var notificationResponses []dtos.NotificationResponse
+	for _, notification := range notifications {
+		notificationResponses = append(notificationResponses, dtos.NotificationResponse{
+			NotificationID: notification.ID,
+			Type:           notification.Type,
+			Message:        notification.Message,
+			ArticleID:      notification.ArticleID,
+			ArticleSlug:    notification.Article.Slug,
+			Thumbnail:      notification.Article.Thumbnail,
+			Read:           notification.ReadAt != nil,
+			ReadAt:         notification.ReadAt,
+			CreatedAt:      notification.CreatedAt,
+		})
+	}
+
+	return notificationResponses, count, nil
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark a notification as read
+// @Description  Mark a notification of the logged in user as read
+// @Tags         User - Notification
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID notification"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/notifications/{id}/read [put]
+// @Security     BearerAuth
+func (u *notificationUsecase) MarkNotificationRead(userId uint, id uint) error {
+	err := u.notificationRepository.MarkNotificationRead(userId, id, time.Now())
+	if err != nil {
+		return errors.New("Notification not found")
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Mark all notifications as read
+// @Description  Mark every notification of the logged in user as read
+// @Tags         User - Notification
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/notifications/read [put]
+// @Security     BearerAuth
+func (u *notificationUsecase) MarkAllNotificationsRead(userId uint) error {
+	err := u.notificationRepository.MarkAllNotificationsRead(userId, time.Now())
+	if err != nil {
+		return errors.New("Failed to mark notifications as read")
+	}
+
+	return nil
+}
@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"log"
+	"time"
+)
+
+// Called when a user passes the quiz of an article for the first time
+type QuizPassedHandler func(userId uint, articleId uint, score int, passedAt time.Time) error
+
+type QuizUsecase interface {
+	GetQuiz(userId uint, articleId uint) (dtos.QuizResponse, error)
+	GetAdminQuiz(articleId uint) (dtos.QuizResponse, error)
+	SaveQuiz(articleId uint, req dtos.QuizRequest) (dtos.QuizResponse, error)
+	DeleteQuiz(articleId uint) error
+	SubmitQuizAttempt(userId uint, articleId uint, req dtos.QuizAttemptRequest) (dtos.QuizAttemptResponse, error)
+	OnQuizPassed(handler QuizPassedHandler)
+}
+
+type quizUsecase struct {
+	quizRepository    repositories.QuizRepository
+	articleRepository repositories.ArticleRepository
+	passedHandlers    []QuizPassedHandler
+}
+
+func NewQuizUsecase(quizRepository repositories.QuizRepository, articleRepository repositories.ArticleRepository) QuizUsecase {
+	return &quizUsecase{
+		quizRepository:    quizRepository,
+		articleRepository: articleRepository,
+	}
+}
+
+// GetQuiz godoc
+// @Summary      Get quiz of an article
+// @Description  Get questions and options of an article quiz without the answers, best score is included for logged in users
+// @Tags         Article - Quiz
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.QuizStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/quiz [get]
+func (u *quizUsecase) GetQuiz(userId uint, articleId uint) (dtos.QuizResponse, error) {
+	article, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil || article.Status != models.ArticlePublished {
+		return dtos.QuizResponse{}, errors.New("Article not found")
+	}
+
+	quiz, err := u.quizRepository.GetQuizByArticleID(article.ID)
+	if err != nil {
+		return dtos.QuizResponse{}, errors.New("Quiz not found")
+	}
+
+	quizResponse := newQuizResponse(quiz, false)
+
+	if userId != 0 {
+		bestScore, err := u.quizRepository.GetBestQuizScore(userId, article.ID)
+		if err != nil {
+			return quizResponse, errors.New("Failed to get best score")
+		}
+		quizResponse.BestScore = &bestScore
+	}
+
+	return quizResponse, nil
+}
+
+// GetAdminQuiz godoc
+// @Summary      Get quiz of an article for admin
+// @Description  Get an article quiz with correct options and explanations
+// @Tags         Admin - Quiz
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.QuizStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/quiz [get]
+// @Security BearerAuth
+func (u *quizUsecase) GetAdminQuiz(articleId uint) (dtos.QuizResponse, error) {
+	quiz, err := u.quizRepository.GetQuizByArticleID(articleId)
+	if err != nil {
+		return dtos.QuizResponse{}, errors.New("Quiz not found")
+	}
+
+	return newQuizResponse(quiz, true), nil
+}
+
+// SaveQuiz godoc
+// @Summary      Create or replace quiz of an article
+// @Description  Attach a multiple choice quiz to an article, every question needs exactly one correct option
+// @Tags         Admin - Quiz
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param        request body dtos.QuizRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.QuizStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/quiz [put]
+// @Security BearerAuth
+func (u *quizUsecase) SaveQuiz(articleId uint, req dtos.QuizRequest) (dtos.QuizResponse, error) {
+	article, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil {
+		return dtos.QuizResponse{}, errors.New("Article not found")
+	}
+
+	quiz := models.Quiz{
+		ArticleID:    article.ID,
+		Title:        req.Title,
+		PassingScore: req.PassingScore,
+	}
+	if quiz.Title == "" {
+		quiz.Title = article.Title
+	}
+	if quiz.PassingScore == 0 {
+		quiz.PassingScore = models.QuizDefaultPassingScore
+	}
+
+	for i, questionReq := range req.Questions {
+		question := models.QuizQuestion{
+			Position:    i + 1,
+			Question:    questionReq.Question,
+			Explanation: questionReq.Explanation,
+		}
+
+		correctOptions := 0
+		for j, optionReq := range questionReq.Options {
+			if optionReq.IsCorrect {
+				correctOptions++
+			}
+			question.Options = append(question.Options, models.QuizOption{
+				Position:  j + 1,
+				Text:      optionReq.Text,
+				IsCorrect: optionReq.IsCorrect,
+			})
+		}
+		if correctOptions != 1 {
+			return dtos.QuizResponse{}, errors.New("Every question needs exactly one correct option")
+		}
+
+		quiz.Questions = append(quiz.Questions, question)
+	}
+
+	quiz, err = u.quizRepository.SaveQuiz(quiz)
+	if err != nil {
+		return dtos.QuizResponse{}, errors.New("Failed to save quiz")
+	}
+
+	return newQuizResponse(quiz, true), nil
+}
+
+// DeleteQuiz godoc
+// @Summary      Delete quiz of an article
+// @Description  Remove the quiz of an article, scores of past attempts are kept
+// @Tags         Admin - Quiz
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /admin/article/{id}/quiz [delete]
+// @Security BearerAuth
+func (u *quizUsecase) DeleteQuiz(articleId uint) error {
+	err := u.quizRepository.DeleteQuiz(articleId)
+	if err != nil {
+		return errors.New("Failed to delete quiz")
+	}
+
+	return nil
+}
+
+// SubmitQuizAttempt godoc
+// @Summary      Submit quiz answers
+// @Description  Score answers of an article quiz, unanswered questions count as wrong, feedback explains every question
+// @Tags         Article - Quiz
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param        request body dtos.QuizAttemptRequest true "Payload Body [RAW]"
+// @Success      201 {object} dtos.QuizAttemptStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/quiz/attempts [post]
+// @Security     BearerAuth
+func (u *quizUsecase) SubmitQuizAttempt(userId uint, articleId uint, req dtos.QuizAttemptRequest) (dtos.QuizAttemptResponse, error) {
+	article, err := u.articleRepository.GetArticleByID(articleId)
+	if err != nil || article.Status != models.ArticlePublished {
+		return dtos.QuizAttemptResponse{}, errors.New("Article not found")
+	}
+
+	quiz, err := u.quizRepository.GetQuizByArticleID(article.ID)
+	if err != nil {
+		return dtos.QuizAttemptResponse{}, errors.New("Quiz not found")
+	}
+
+	selected := map[uint]uint{}
+	for _, answer := range req.Answers {
+		if _, ok := selected[answer.QuestionID]; !ok {
+			selected[answer.QuestionID] = answer.OptionID
+		}
+	}
+
+	attempt := models.QuizAttempt{
+		UserID:         userId,
+		ArticleID:      article.ID,
+		TotalQuestions: len(quiz.Questions),
+	}
+
+	var feedback []dtos.QuizFeedbackResponse
+	for _, question := range quiz.Questions {
+		optionId, answered := selected[question.ID]
+		delete(selected, question.ID)
+
+		var correctOptionId uint
+		validOption := false
+		for _, option := range question.Options {
+			if option.IsCorrect {
+				correctOptionId = option.ID
+			}
+			if option.ID == optionId {
+				validOption = true
+			}
+		}
+		if answered && !validOption {
+			return dtos.QuizAttemptResponse{}, errors.New("Answer is not an option of the question")
+		}
+
+		correct := answered && optionId == correctOptionId
+		if correct {
+			attempt.CorrectAnswers++
+		}
+		if answered {
+			attempt.Answers = append(attempt.Answers, models.QuizAnswer{
+				QuestionID: question.ID,
+				OptionID:   optionId,
+				Correct:    correct,
+			})
+		}
+
+		feedback = append(feedback, dtos.QuizFeedbackResponse{
+			QuestionID:       question.ID,
+			SelectedOptionID: optionId,
+			CorrectOptionID:  correctOptionId,
+			Correct:          correct,
+			Explanation:      question.Explanation,
+		})
+	}
+
+	// Answers left over belong to another quiz or an old version of this one
+	if len(selected) > 0 {
+		return dtos.QuizAttemptResponse{}, errors.New("Answer is not a question of the quiz")
+	}
+
+	if attempt.TotalQuestions > 0 {
+		attempt.Score = (attempt.CorrectAnswers*100 + attempt.TotalQuestions/2) / attempt.TotalQuestions
+	}
+	attempt.Passed = attempt.Score >= quiz.PassingScore
+
+	passedBefore, err := u.quizRepository.HasPassedQuiz(userId, article.ID)
+	if err != nil {
+		return dtos.QuizAttemptResponse{}, errors.New("Failed to get previous attempts")
+	}
+
+	attempt, err = u.quizRepository.CreateQuizAttempt(attempt)
+	if err != nil {
+		return dtos.QuizAttemptResponse{}, errors.New("Failed to save quiz attempt")
+	}
+
+	if attempt.Passed && !passedBefore {
+		u.notifyQuizPassed(userId, article.ID, attempt.Score, attempt.CreatedAt)
+	}
+
+	bestScore, err := u.quizRepository.GetBestQuizScore(userId, article.ID)
+	if err != nil {
+		return dtos.QuizAttemptResponse{}, errors.New("Failed to get best score")
+	}
+
+	return dtos.QuizAttemptResponse{
+		AttemptID:      attempt.ID,
+		ArticleID:      attempt.ArticleID,
+		Score:          attempt.Score,
+		CorrectAnswers: attempt.CorrectAnswers,
+		TotalQuestions: attempt.TotalQuestions,
+		Passed:         attempt.Passed,
+		BestScore:      bestScore,
+		Feedback:       feedback,
+		CreatedAt:      attempt.CreatedAt,
+	}, nil
+}
+
+// Register a handler for first quiz passes, handlers are registered while wiring routes
+func (u *quizUsecase) OnQuizPassed(handler QuizPassedHandler) {
+	u.passedHandlers = append(u.passedHandlers, handler)
+}
+
+// Attempt is already saved, a failing handler is logged and does not fail the request
+func (u *quizUsecase) notifyQuizPassed(userId uint, articleId uint, score int, passedAt time.Time) {
+	for _, handler := range u.passedHandlers {
+		err := handler(userId, articleId, score, passedAt)
+		if err != nil {
+			log.Printf("quiz: passed handler: user %d article %d: %v", userId, articleId, err)
+		}
+	}
+}
+
+// Answers and explanations are left out unless withAnswers is set
+func newQuizResponse(quiz models.Quiz, withAnswers bool) dtos.QuizResponse {
+	quizResponse := dtos.QuizResponse{
+		QuizID:       quiz.ID,
+		ArticleID:    quiz.ArticleID,
+		Title:        quiz.Title,
+		PassingScore: quiz.PassingScore,
+		Questions:    []dtos.QuizQuestionResponse{},
+		UpdatedAt:    quiz.UpdatedAt,
+	}
+
+	for _, question := range quiz.Questions {
+		questionResponse := dtos.QuizQuestionResponse{
+			QuestionID: question.ID,
+			Question:   question.Question,
+			Options:    []dtos.QuizOptionResponse{},
+		}
+		if withAnswers {
+			questionResponse.Explanation = question.Explanation
+		}
+
+		for _, option := range question.Options {
+			optionResponse := dtos.QuizOptionResponse{
+				OptionID: option.ID,
+				Text:     option.Text,
+			}
+			if withAnswers {
+				isCorrect := option.IsCorrect
+				optionResponse.IsCorrect = &isCorrect
+			}
+			questionResponse.Options = append(questionResponse.Options, optionResponse)
+		}
+
+		quizResponse.Questions = append(quizResponse.Questions, questionResponse)
+	}
+
+	return quizResponse
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Number of articles kept in every cached ranking
+	rankingSize = 100
+	// A like is worth this many views in the trending score
+	trendingLikeWeight = 3.0
+	// Activity loses half of its trending weight every this many days
+	trendingHalfLifeDays = 2.0
+)
+
+// Rebuild cached trending and popular rankings in background so the
+// ranking endpoints never aggregate article_likeds on request.
+type RankingJob interface {
+	Start()
+	Stop()
+	RunOnce()
+}
+
+type rankingJob struct {
+	rankingRepository repositories.ArticleRankingRepository
+	interval          time.Duration
+	trendingDays      int
+	stop              chan struct{}
+
+	mu      sync.Mutex
+	running bool
+}
+
+func NewRankingJob(rankingRepository repositories.ArticleRankingRepository, interval time.Duration, trendingDays int) RankingJob {
+	return &rankingJob{
+		rankingRepository: rankingRepository,
+		interval:          interval,
+		trendingDays:      trendingDays,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start background loop that rebuilds the rankings every interval
+func (j *rankingJob) Start() {
+	j.mu.Lock()
+	if j.running {
+		j.mu.Unlock()
+		return
+	}
+	j.running = true
+	j.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		j.RunOnce()
+		for {
+			select {
+			case <-ticker.C:
+				j.RunOnce()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop background loop
+func (j *rankingJob) Stop() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.running {
+		return
+	}
+	j.running = false
+	close(j.stop)
+}
+
+// Rebuild every ranking, a failed ranking keeps its previous cache
+func (j *rankingJob) RunOnce() {
+	now := time.Now()
+
+	if err := j.rankTrending(now); err != nil {
+		log.Printf("ranking job: trending: %v", err)
+	}
+
+	week := now.AddDate(0, 0, -7)
+	month := now.AddDate(0, -1, 0)
+	periods := map[string]*time.Time{
+		models.RankingPopularWeek:  &week,
+		models.RankingPopularMonth: &month,
+		models.RankingPopularAll:   nil,
+	}
+	for kind, since := range periods {
+		if err := j.rankPopular(kind, since, now); err != nil {
+			log.Printf("ranking job: %s: %v", kind, err)
+		}
+	}
+}
+
+// Score likes and views of the last days, older activity weighs less
+func (j *rankingJob) rankTrending(now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	since := today.AddDate(0, 0, 1-j.trendingDays)
+
+	likes, err := j.rankingRepository.GetDailyLikes(since)
+	if err != nil {
+		return err
+	}
+
+	views, err := j.rankingRepository.GetDailyViews(since)
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[uint]*models.ArticleRanking)
+	for _, activity := range append(likes, views...) {
+		ranking, ok := scores[activity.ArticleID]
+		if !ok {
+			ranking = &models.ArticleRanking{Kind: models.RankingTrending, ArticleID: activity.ArticleID, ComputedAt: now}
+			scores[activity.ArticleID] = ranking
+		}
+
+		age := today.Sub(activity.Day).Hours() / 24
+		if age < 0 {
+			age = 0
+		}
+		decay := math.Pow(0.5, age/trendingHalfLifeDays)
+
+		ranking.Likes += activity.Likes
+		ranking.Views += activity.Views
+		ranking.Score += (trendingLikeWeight*float64(activity.Likes) + float64(activity.Views)) * decay
+	}
+
+	rankings := make([]models.ArticleRanking, 0, len(scores))
+	for _, ranking := range scores {
+		rankings = append(rankings, *ranking)
+	}
+	sort.Slice(rankings, func(a, b int) bool {
+		if rankings[a].Score != rankings[b].Score {
+			return rankings[a].Score > rankings[b].Score
+		}
+		return rankings[a].ArticleID < rankings[b].ArticleID
+	})
+	if len(rankings) > rankingSize {
+		rankings = rankings[:rankingSize]
+	}
+	for i := range rankings {
+		rankings[i].Position = i + 1
+		rankings[i].Score = math.Round(rankings[i].Score*100) / 100
+	}
+
+	return j.rankingRepository.ReplaceRankings(models.RankingTrending, rankings)
+}
+
+// Rank articles by likes received in the period
+func (j *rankingJob) rankPopular(kind string, since *time.Time, now time.Time) error {
+	activities, err := j.rankingRepository.GetMostLiked(since, rankingSize)
+	if err != nil {
+		return err
+	}
+
+	rankings := make([]models.ArticleRanking, 0, len(activities))
+	for i, activity := range activities {
+		rankings = append(rankings, models.ArticleRanking{
+			Kind:       kind,
+			Position:   i + 1,
+			ArticleID:  activity.ArticleID,
+			Score:      float64(activity.Likes),
+			Likes:      activity.Likes,
+			ComputedAt: now,
+		})
+	}
+
+	return j.rankingRepository.ReplaceRankings(kind, rankings)
+}
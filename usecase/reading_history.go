@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"log"
+	"time"
+)
+
+// Called once when a user reaches the end of an article, other features hook into completions with it
+type ReadingCompletedHandler func(userId uint, articleId uint, completedAt time.Time) error
+
+type ReadingHistoryUsecase interface {
+	GetReadingHistory(userId uint, status string, page, limit int) ([]dtos.ReadingHistoryResponse, int, error)
+	SaveReadingProgress(userId uint, articleId uint, req dtos.ReadingProgressRequest) (dtos.ReadingHistoryResponse, error)
+	DeleteReadingHistory(userId uint, articleId uint) error
+	ClearReadingHistory(userId uint) error
+	OnReadingCompleted(handler ReadingCompletedHandler)
+}
+
+type readingHistoryUsecase struct {
+	historyRepository repositories.ReadingHistoryRepository
+	articleUsecase    ArticleUsecase
+	completedHandlers []ReadingCompletedHandler
+}
+
+func NewReadingHistoryUsecase(historyRepository repositories.ReadingHistoryRepository, articleUsecase ArticleUsecase) ReadingHistoryUsecase {
+	return &readingHistoryUsecase{
+		historyRepository: historyRepository,
+		articleUsecase:    articleUsecase,
+	}
+}
+
+// GetReadingHistory godoc
+// @Summary      Get reading history
+// @Description  Get articles read by the logged in user, unfinished articles first to continue reading
+// @Tags         User - Reading History
+// @Accept       json
+// @Produce      json
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Param status query string false "in_progress or completed"
+// @Success      200 {object} dtos.GetAllReadingHistoryStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/history [get]
+// @Security     BearerAuth
+func (u *readingHistoryUsecase) GetReadingHistory(userId uint, status string, page, limit int) ([]dtos.ReadingHistoryResponse, int, error) {
+	var completed *bool
+	switch status {
+	case "":
+	case "in_progress", "completed":
+		isCompleted := status == "completed"
+		completed = &isCompleted
+	default:
+		return nil, 0, errors.New("Reading status is not valid")
+	}
+
+	histories, count, err := u.historyRepository.GetReadingHistories(userId, completed, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get reading history")
+	}
+
+	var articleIds []uint
+	for _, history := range histories {
+		articleIds = append(articleIds, history.ArticleID)
+	}
+
+	articles, err := u.articleUsecase.GetArticlesByIDs(articleIds)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	articleByID := map[uint]dtos.ArticleDetailResponse{}
+	for _, article := range articles {
+		articleByID[article.ArticleID] = article
+	}
+
+	var historyResponses []dtos.ReadingHistoryResponse
+	for _, history := range histories {
+		historyResponse := newReadingHistoryResponse(history)
+		if article, ok := articleByID[history.ArticleID]; ok {
+			historyResponse.Article = &article
+		}
+		historyResponses = append(historyResponses, historyResponse)
+	}
+
+	return historyResponses, count, nil
+}
+
+// SaveReadingProgress godoc
+// @Summary      Save reading progress
+// @Description  Report how far the logged in user has read an article in percent, the article is completed from 90 percent
+// @Tags         Article
+// @Accept       json
+// @Produce      json
+// @Param id path integer true "ID article"
+// @Param        request body dtos.ReadingProgressRequest true "Payload Body [RAW]"
+// @Success      200 {object} dtos.ReadingProgressStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /article/{id}/progress [put]
+// @Security     BearerAuth
+func (u *readingHistoryUsecase) SaveReadingProgress(userId uint, articleId uint, req dtos.ReadingProgressRequest) (dtos.ReadingHistoryResponse, error) {
+	_, err := u.articleUsecase.GetArticleByID(articleId)
+	if err != nil {
+		return dtos.ReadingHistoryResponse{}, errors.New("Article not found")
+	}
+
+	now := time.Now()
+
+	history, err := u.historyRepository.SaveReadingProgress(userId, articleId, req.Progress, now)
+	if err != nil {
+		return dtos.ReadingHistoryResponse{}, errors.New("Failed to save reading progress")
+	}
+
+	// Progress is saved, a missed reading day only affects the streak
+	err = u.historyRepository.SaveReadingActivity(userId, now)
+	if err != nil {
+		log.Printf("reading history: reading activity: user %d: %v", userId, err)
+	}
+
+	if req.Progress >= models.ReadingCompleteProgress && history.CompletedAt == nil {
+		completed, err := u.historyRepository.MarkReadingCompleted(history, now)
+		if err != nil {
+			return dtos.ReadingHistoryResponse{}, errors.New("Failed to complete article")
+		}
+
+		// Concurrent reports may race here, only the one that set the completion notifies
+		if completed {
+			history.CompletedAt = &now
+			u.notifyReadingCompleted(userId, articleId, now)
+		}
+	}
+
+	return newReadingHistoryResponse(history), nil
+}
+
+// DeleteReadingHistory godoc
+// @Summary      Delete an article from reading history
+// @Description  Remove one article from the reading history of the logged in user
+// @Tags         User - Reading History
+// @Accept       json
+// @Produce      json
+// @Param article_id path integer true "ID article"
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/history/{article_id} [delete]
+// @Security     BearerAuth
+func (u *readingHistoryUsecase) DeleteReadingHistory(userId uint, articleId uint) error {
+	err := u.historyRepository.DeleteReadingHistory(userId, articleId)
+	if err != nil {
+		return errors.New("Failed to delete reading history")
+	}
+
+	return nil
+}
+
+// ClearReadingHistory godoc
+// @Summary      Clear reading history
+// @Description  Remove every article from the reading history of the logged in user
+// @Tags         User - Reading History
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} dtos.StatusOKDeletedResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /user/history [delete]
+// @Security     BearerAuth
+func (u *readingHistoryUsecase) ClearReadingHistory(userId uint) error {
+	err := u.historyRepository.DeleteReadingHistories(userId)
+	if err != nil {
+		return errors.New("Failed to clear reading history")
+	}
+
+	return nil
+}
+
+// Register a handler for article completions, handlers are registered while wiring routes
+func (u *readingHistoryUsecase) OnReadingCompleted(handler ReadingCompletedHandler) {
+	u.completedHandlers = append(u.completedHandlers, handler)
+}
+
+// Completion is already saved, a failing handler is logged and does not fail the request
+func (u *readingHistoryUsecase) notifyReadingCompleted(userId uint, articleId uint, completedAt time.Time) {
+	for _, handler := range u.completedHandlers {
+		err := handler(userId, articleId, completedAt)
+		if err != nil {
+			log.Printf("reading history: completed handler: user %d article %d: %v", userId, articleId, err)
+		}
+	}
+}
+
+func newReadingHistoryResponse(history models.ReadingHistory) dtos.ReadingHistoryResponse {
+	return dtos.ReadingHistoryResponse{
+		ArticleID:   history.ArticleID,
+		Progress:    history.Progress,
+		Completed:   history.CompletedAt != nil,
+		CompletedAt: history.CompletedAt,
+		LastReadAt:  history.LastReadAt,
+	}
+}
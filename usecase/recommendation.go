@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"go_bedu/helpers"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"log"
+	"sort"
+	"sync"
+)
+
+const (
+	// Related articles stored for every article
+	relatedArticlesKept = 20
+	// Pairs less similar than this are not worth recommending
+	minRelatedScore = 0.05
+
+	textSimilarityWeight     = 0.6
+	categorySimilarityWeight = 0.25
+	tagSimilarityWeight      = 0.15
+)
+
+// Precompute related articles from shared category, shared tags and
+// TF-IDF similarity of title, abstract and description.
+//
+// Saving articles only queues a refresh, one background worker rebuilds the
+// corpus for every article queued since its last batch.
+type ArticleRecommender interface {
+	Start()
+	Stop()
+	QueueRefresh(articleID uint)
+	RefreshPublished(article models.Article) error
+	GetRelated(articleID uint, limit int) ([]models.ArticleRelation, error)
+	Refresh(articleID uint) error
+	RefreshAll() error
+	MigrateRelations() error
+}
+
+type articleRecommender struct {
+	relationRepository repositories.ArticleRelationRepository
+	queued             chan struct{}
+	stop               chan struct{}
+
+	mu      sync.Mutex
+	running bool
+	pending map[uint]bool
+}
+
+func NewArticleRecommender(relationRepository repositories.ArticleRelationRepository) ArticleRecommender {
+	return &articleRecommender{
+		relationRepository: relationRepository,
+		queued:             make(chan struct{}, 1),
+		stop:               make(chan struct{}),
+		pending:            make(map[uint]bool),
+	}
+}
+
+// Article prepared for similarity scoring
+type recommendationDocument struct {
+	categoryID *uint
+	tags       map[uint]bool
+	vector     map[string]float64
+}
+
+// Start background worker, related Articles of existing Articles are computed first when missing
+func (r *articleRecommender) Start() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	go func() {
+		if err := r.MigrateRelations(); err != nil {
+			log.Printf("recommendation: %v", err)
+		}
+
+		for {
+			select {
+			case <-r.queued:
+				r.refreshPending()
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop background worker, refreshes still queued are dropped
+func (r *articleRecommender) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return
+	}
+	r.running = false
+	close(r.stop)
+}
+
+// Queue related Articles of the Article for the worker, an Article queued twice is refreshed once
+func (r *articleRecommender) QueueRefresh(articleID uint) {
+	r.mu.Lock()
+	r.pending[articleID] = true
+	r.mu.Unlock()
+
+	select {
+	case r.queued <- struct{}{}:
+	default:
+		// Worker is already woken up and takes the new Article with the batch
+	}
+}
+
+// Refresh every queued Article with one load of the corpus
+func (r *articleRecommender) refreshPending() {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[uint]bool)
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	articleIDs := make([]uint, 0, len(pending))
+	for articleID := range pending {
+		articleIDs = append(articleIDs, articleID)
+	}
+
+	if err := r.refresh(articleIDs); err != nil {
+		log.Printf("recommendation: articles %v: %v", articleIDs, err)
+	}
+}
+
+// Get stored related Articles, most similar first
+func (r *articleRecommender) GetRelated(articleID uint, limit int) ([]models.ArticleRelation, error) {
+	return r.relationRepository.GetRelatedArticles(articleID, limit)
+}
+
+// Recompute related Articles of one Article and its place in the lists of other Articles
+func (r *articleRecommender) Refresh(articleID uint) error {
+	return r.refresh([]uint{articleID})
+}
+
+// Published handler, scheduled articles enter the related lists once they are published
+func (r *articleRecommender) RefreshPublished(article models.Article) error {
+	r.QueueRefresh(article.ID)
+
+	return nil
+}
+
+// Recompute the lists of the Articles and merge them into the stored lists of other Articles.
+// Articles that are no longer published are removed, lists that lose them are recomputed to fill the gap.
+// Every changed list is written in one batch.
+func (r *articleRecommender) refresh(articleIDs []uint) error {
+	documents, err := r.documents()
+	if err != nil {
+		return err
+	}
+
+	stored, err := r.relationRepository.GetAllRelations()
+	if err != nil {
+		return err
+	}
+
+	lists := make(map[uint][]models.ArticleRelation)
+	for _, relation := range stored {
+		lists[relation.ArticleID] = append(lists[relation.ArticleID], relation)
+	}
+
+	changed := make(map[uint][]models.ArticleRelation)
+	for _, articleID := range articleIDs {
+		target, published := documents[articleID]
+		if !published {
+			changed[articleID] = nil
+			delete(lists, articleID)
+		} else {
+			lists[articleID] = relatedRelations(documents, articleID)
+			changed[articleID] = lists[articleID]
+		}
+
+		// Lists of unpublished Articles are never shown so only published lists are merged
+		for id, document := range documents {
+			if id == articleID {
+				continue
+			}
+
+			list := lists[id]
+			listed := false
+			others := make([]models.ArticleRelation, 0, len(list)+1)
+			for _, relation := range list {
+				if relation.RelatedID == articleID {
+					listed = true
+					continue
+				}
+				others = append(others, relation)
+			}
+
+			score := 0.0
+			if published {
+				score = similarity(document, target)
+			}
+			if !listed && score < minRelatedScore {
+				continue
+			}
+
+			// Similarity is symmetric so the new score is placed in the other list directly,
+			// a list that drops the Article may have a better candidate outside of it
+			if listed && len(list) >= relatedArticlesKept && score < list[len(list)-1].Score {
+				lists[id] = relatedRelations(documents, id)
+			} else {
+				if score >= minRelatedScore {
+					others = append(others, models.ArticleRelation{ArticleID: id, RelatedID: articleID, Score: score})
+				}
+				lists[id] = topRelations(others)
+			}
+			changed[id] = lists[id]
+		}
+	}
+
+	return r.relationRepository.SaveRelations(changed)
+}
+
+// Recompute related Articles of every Article
+func (r *articleRecommender) RefreshAll() error {
+	documents, err := r.documents()
+	if err != nil {
+		return err
+	}
+
+	lists := make(map[uint][]models.ArticleRelation, len(documents))
+	for articleID := range documents {
+		lists[articleID] = relatedRelations(documents, articleID)
+	}
+
+	return r.relationRepository.SaveRelations(lists)
+}
+
+// Most similar published Articles of one Article
+func relatedRelations(documents map[uint]recommendationDocument, articleID uint) []models.ArticleRelation {
+	target := documents[articleID]
+
+	var relations []models.ArticleRelation
+	for id, document := range documents {
+		if id == articleID {
+			continue
+		}
+
+		score := similarity(target, document)
+		if score < minRelatedScore {
+			continue
+		}
+		relations = append(relations, models.ArticleRelation{ArticleID: articleID, RelatedID: id, Score: score})
+	}
+
+	return topRelations(relations)
+}
+
+// Compute related Articles of existing Articles when none were computed yet
+func (r *articleRecommender) MigrateRelations() error {
+	total, err := r.relationRepository.CountRelations()
+	if err != nil || total > 0 {
+		return err
+	}
+
+	return r.RefreshAll()
+}
+
+// Load every published Article and build its TF-IDF vector, titles weigh the most
+func (r *articleRecommender) documents() (map[uint]recommendationDocument, error) {
+	articles, err := r.relationRepository.GetRecommendationCorpus()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]map[string]float64, len(articles))
+	for _, article := range articles {
+		terms := make(map[string]float64)
+		helpers.AddTermCounts(terms, article.Title, 3)
+		helpers.AddTermCounts(terms, article.Abstract, 2)
+		helpers.AddTermCounts(terms, helpers.MarkdownText(article.Description), 1)
+		counts[article.ID] = terms
+	}
+	vectors := helpers.TFIDFVectors(counts)
+
+	documents := make(map[uint]recommendationDocument, len(articles))
+	for _, article := range articles {
+		tags := make(map[uint]bool, len(article.Tags))
+		for _, tag := range article.Tags {
+			tags[tag.ID] = true
+		}
+
+		documents[article.ID] = recommendationDocument{
+			categoryID: article.CategoryID,
+			tags:       tags,
+			vector:     vectors[article.ID],
+		}
+	}
+
+	return documents, nil
+}
+
+// Weighted similarity between 0 and 1
+func similarity(a, b recommendationDocument) float64 {
+	score := textSimilarityWeight * helpers.CosineSimilarity(a.vector, b.vector)
+
+	if a.categoryID != nil && b.categoryID != nil && *a.categoryID == *b.categoryID {
+		score += categorySimilarityWeight
+	}
+
+	// Jaccard index of the tags
+	shared := 0
+	for id := range a.tags {
+		if b.tags[id] {
+			shared++
+		}
+	}
+	if union := len(a.tags) + len(b.tags) - shared; union > 0 {
+		score += tagSimilarityWeight * float64(shared) / float64(union)
+	}
+
+	return score
+}
+
+// Most similar relations first, limited to the stored amount
+func topRelations(relations []models.ArticleRelation) []models.ArticleRelation {
+	sort.Slice(relations, func(a, b int) bool {
+		if relations[a].Score != relations[b].Score {
+			return relations[a].Score > relations[b].Score
+		}
+		return relations[a].RelatedID < relations[b].RelatedID
+	})
+
+	if len(relations) > relatedArticlesKept {
+		return relations[:relatedArticlesKept]
+	}
+
+	return relations
+}
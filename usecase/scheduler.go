@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"go_bedu/dtos"
+	"go_bedu/models"
+	"go_bedu/repositories"
+	"log"
+	"sync"
+	"time"
+)
+
+type PublishScheduler interface {
+	Start()
+	Stop()
+	RunOnce()
+	Status() dtos.SchedulerStatusResponse
+	OnArticlePublished(handler ArticlePublishedHandler)
+}
+
+type publishScheduler struct {
+	articleRepository repositories.ArticleRepository
+	interval          time.Duration
+	stop              chan struct{}
+
+	mu             sync.RWMutex
+	running        bool
+	lastRunAt      *time.Time
+	nextRunAt      *time.Time
+	lastPublished  int
+	totalPublished int
+	lastError      string
+	handlers       []ArticlePublishedHandler
+}
+
+func NewPublishScheduler(articleRepository repositories.ArticleRepository, interval time.Duration) PublishScheduler {
+	return &publishScheduler{
+		articleRepository: articleRepository,
+		interval:          interval,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start background loop that publishes due articles every interval
+func (s *publishScheduler) Start() {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.RunOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.RunOnce()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop background loop
+func (s *publishScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+	s.running = false
+	close(s.stop)
+}
+
+// Register a handler for scheduled articles, register before Start so the first run is handled too
+func (s *publishScheduler) OnArticlePublished(handler ArticlePublishedHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = append(s.handlers, handler)
+}
+
+// Publish articles whose schedule is due and record the result
+func (s *publishScheduler) RunOnce() {
+	now := time.Now()
+	articles, err := s.articleRepository.PublishScheduledArticles(now)
+
+	s.record(now, articles, err)
+	if err != nil {
+		return
+	}
+
+	s.mu.RLock()
+	handlers := s.handlers
+	s.mu.RUnlock()
+
+	for _, article := range articles {
+		for _, handler := range handlers {
+			err := handler(article)
+			if err != nil {
+				log.Printf("publish scheduler: published handler: article %d: %v", article.ID, err)
+			}
+		}
+	}
+}
+
+// Save the result of a run for the health endpoint
+func (s *publishScheduler) record(now time.Time, articles []models.Article, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := now.Add(s.interval)
+	s.lastRunAt = &now
+	s.nextRunAt = &next
+	s.lastPublished = len(articles)
+	s.totalPublished += len(articles)
+	s.lastError = ""
+
+	if err != nil {
+		s.lastPublished = 0
+		s.lastError = err.Error()
+		log.Printf("publish scheduler: %v", err)
+		return
+	}
+
+	for _, article := range articles {
+		log.Printf("publish scheduler: article %d published", article.ID)
+	}
+}
+
+// Snapshot of the scheduler state for the health endpoint
+func (s *publishScheduler) Status() dtos.SchedulerStatusResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return dtos.SchedulerStatusResponse{
+		Running:        s.running,
+		Interval:       s.interval.String(),
+		LastRunAt:      s.lastRunAt,
+		NextRunAt:      s.nextRunAt,
+		LastPublished:  s.lastPublished,
+		TotalPublished: s.totalPublished,
+		LastError:      s.lastError,
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/initializers"
+	"go_bedu/repositories"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Maximum URLs of a single sitemap file defined by the sitemap protocol
+const sitemapMaxURLs = 50000
+
+type SitemapUsecase interface {
+	GetSitemap(page int, baseURL string) (dtos.FeedResponse, error)
+}
+
+type sitemapUsecase struct {
+	articleRepository  repositories.ArticleRepository
+	categoryRepository repositories.CategoryRepository
+}
+
+func NewSitemapUsecase(articleRepository repositories.ArticleRepository, categoryRepository repositories.CategoryRepository) SitemapUsecase {
+	return &sitemapUsecase{articleRepository, categoryRepository}
+}
+
+// GetSitemap godoc
+// @Summary      XML sitemap
+// @Description  Sitemap of categories and published articles. Over 50000 URLs it returns a sitemap index, pages are read with ?page=
+// @Tags         Feed
+// @Produce      xml
+// @Param page query int false "Sitemap page listed in the sitemap index"
+// @Success      200 {object} dtos.SitemapURLSet
+// @Success      304
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /sitemap.xml [get]
+func (u *sitemapUsecase) GetSitemap(page int, baseURL string) (dtos.FeedResponse, error) {
+	config, err := initializers.LoadConfig(".")
+	if err != nil {
+		return dtos.FeedResponse{}, errors.New("Failed to load config")
+	}
+
+	categories, err := u.categoryRepository.GetCategories()
+	if err != nil {
+		return dtos.FeedResponse{}, errors.New("Failed to get categories")
+	}
+
+	// Empty categories only show an empty page, leave them out
+	var categoryURLs []dtos.SitemapURL
+	var lastModified time.Time
+	for _, category := range categories {
+		if category.ArticleCount == 0 {
+			continue
+		}
+
+		categoryURLs = append(categoryURLs, dtos.SitemapURL{
+			Loc:        config.ClientOrigin + "/#/category/" + url.PathEscape(category.Slug),
+			ChangeFreq: "daily",
+			Priority:   "0.6",
+		})
+	}
+
+	_, articleCount, err := u.articleRepository.GetSitemapArticles(0, 0)
+	if err != nil {
+		return dtos.FeedResponse{}, errors.New("Failed to get articles")
+	}
+
+	total := len(categoryURLs) + articleCount
+	pages := (total + sitemapMaxURLs - 1) / sitemapMaxURLs
+
+	if page == 0 && pages > 1 {
+		index := dtos.SitemapIndex{}
+		for i := 1; i <= pages; i++ {
+			index.Sitemaps = append(index.Sitemaps, dtos.SitemapEntry{Loc: baseURL + "?page=" + strconv.Itoa(i)})
+		}
+
+		return renderFeed(index, "application/xml; charset=utf-8", lastModified)
+	}
+
+	if page == 0 {
+		page = 1
+	}
+	if page > 1 && page > pages {
+		return dtos.FeedResponse{}, errors.New("Sitemap page not found")
+	}
+
+	// Categories fill the first page, articles follow in ID order
+	start := (page - 1) * sitemapMaxURLs
+	urlSet := dtos.SitemapURLSet{URLs: []dtos.SitemapURL{}}
+	if start < len(categoryURLs) {
+		urlSet.URLs = append(urlSet.URLs, categoryURLs[start:]...)
+	}
+
+	offset := start - len(categoryURLs)
+	if offset < 0 {
+		offset = 0
+	}
+
+	articles, _, err := u.articleRepository.GetSitemapArticles(offset, sitemapMaxURLs-len(urlSet.URLs))
+	if err != nil {
+		return dtos.FeedResponse{}, errors.New("Failed to get articles")
+	}
+
+	for _, article := range articles {
+		urlSet.URLs = append(urlSet.URLs, dtos.SitemapURL{
+			Loc:        articleLink(config.ClientOrigin, article.Slug),
+			LastMod:    article.UpdatedAt.UTC().Format(time.RFC3339),
+			ChangeFreq: "weekly",
+			Priority:   "0.8",
+		})
+
+		if article.UpdatedAt.After(lastModified) {
+			lastModified = article.UpdatedAt
+		}
+	}
+
+	return renderFeed(urlSet, "application/xml; charset=utf-8", lastModified)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"errors"
+	"go_bedu/dtos"
+	"go_bedu/repositories"
+)
+
+type TagUsecase interface {
+	GetTagCloud(limit int) ([]dtos.TagCloudResponse, error)
+	GetArticlesByTag(slug string, page, limit int) ([]dtos.ArticleDetailResponse, int, error)
+}
+
+type tagUsecase struct {
+	tagRepository  repositories.TagRepository
+	articleUsecase ArticleUsecase
+}
+
+func NewTagUsecase(tagRepository repositories.TagRepository, articleUsecase ArticleUsecase) TagUsecase {
+	return &tagUsecase{tagRepository, articleUsecase}
+}
+
+// GetTagCloud godoc
+// @Summary      Get tag cloud
+// @Description  Get most used tags with their article count
+// @Tags         Tag
+// @Accept       json
+// @Produce      json
+// @Param limit query int false "Number of tags"
+// @Success      200 {object} dtos.TagCloudStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /tag/cloud [get]
+func (u *tagUsecase) GetTagCloud(limit int) ([]dtos.TagCloudResponse, error) {
+	tags, err := u.tagRepository.GetTagCloud(limit)
+	if err != nil {
+		return nil, errors.New("Failed to get tag cloud")
+	}
+
+	tagResponses := []dtos.TagCloudResponse{}
+	for _, tag := range tags {
+		tagResponses = append(tagResponses, dtos.TagCloudResponse{
+			TagID:        tag.ID,
+			Name:         tag.Name,
+			Slug:         tag.Slug,
+			ArticleCount: tag.ArticleCount,
+		})
+	}
+
+	return tagResponses, nil
+}
+
+// GetArticlesByTag godoc
+// @Summary      Get articles by tag
+// @Description  Get articles by tag slug
+// @Tags         Tag
+// @Accept       json
+// @Produce      json
+// @Param slug path string true "Slug tag"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success      200 {object} dtos.GetAllArticleStatusOKResponse
+// @Failure      400 {object} dtos.BadRequestResponse
+// @Failure      401 {object} dtos.UnauthorizedResponse
+// @Failure      403 {object} dtos.ForbiddenResponse
+// @Failure      404 {object} dtos.NotFoundResponse
+// @Failure      500 {object} dtos.InternalServerErrorResponse
+// @Router       /tag/{slug}/articles [get]
+func (u *tagUsecase) GetArticlesByTag(slug string, page, limit int) ([]dtos.ArticleDetailResponse, int, error) {
+	tag, err := u.tagRepository.GetTagBySlug(slug)
+	if err != nil {
+		return nil, 0, errors.New("Tag not found")
+	}
+
+	articles, count, err := u.articleUsecase.GetAllArticles(dtos.ArticleFilter{Tag: tag.Slug}, page, limit)
+	if err != nil {
+		return nil, 0, errors.New("Failed to get articles")
+	}
+
+	return articles, count, nil
+}
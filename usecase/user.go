@@ -10,16 +10,21 @@ import (
 	"go_bedu/models"
 	"go_bedu/repositories"
 	"go_bedu/utils"
+	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/thanhpk/randstr"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Called once when a user verifies the email address
+type EmailVerifiedHandler func(userId uint, verifiedAt time.Time) error
+
 type UserUsecase interface {
 	LoginUser(c echo.Context, req dtos.LoginRequest) (res dtos.LoginResponse, err error)
 	LogoutUser(c echo.Context) (res dtos.LogoutUserResponse, err error)
@@ -32,14 +37,25 @@ type UserUsecase interface {
 	CreateUser(req *dtos.RegisterUserRequest) (dtos.UserDetailResponse, error)
 	UpdateUser(id uint, req dtos.UpdateUserRequest) (res dtos.UpdateUserResponse, err error)
 	DeleteUser(id uint, req dtos.DeleteUserRequest) (res helpers.ResponseMessage, err error)
+	OnEmailVerified(handler EmailVerifiedHandler)
 }
 
 type userUsecase struct {
-	userRepository repositories.UserRepository
+	userRepository        repositories.UserRepository
+	quizRepository        repositories.QuizRepository
+	emailVerifiedHandlers []EmailVerifiedHandler
+}
+
+func NewUserUsecase(userRepository repositories.UserRepository, quizRepository repositories.QuizRepository) *userUsecase {
+	return &userUsecase{
+		userRepository: userRepository,
+		quizRepository: quizRepository,
+	}
 }
 
-func NewUserUsecase(userRepository repositories.UserRepository) *userUsecase {
-	return &userUsecase{userRepository}
+// Register a handler for email verifications, handlers are registered while wiring routes
+func (u *userUsecase) OnEmailVerified(handler EmailVerifiedHandler) {
+	u.emailVerifiedHandlers = append(u.emailVerifiedHandlers, handler)
 }
 
 func (u *userUsecase) MustDispEmailDom() (dispEmailDomains []string, err error) {
@@ -153,7 +169,18 @@ func (u *userUsecase) VerifyEmail(verificationCode any) (res dtos.VerifyEmailRes
 	user.VerificationCode = ""
 	user.Verified = true
 
-	u.userRepository.UpdateUser(user)
+	user, err = u.userRepository.UpdateUser(user)
+	if err != nil {
+		return res, errors.New("Failed to verify email")
+	}
+
+	// Verification is already saved, a failing handler is logged
+	for _, handler := range u.emailVerifiedHandlers {
+		err := handler(user.ID, time.Now())
+		if err != nil {
+			log.Printf("user: email verified handler: user %d: %v", user.ID, err)
+		}
+	}
 
 	res = dtos.VerifyEmailResponse{
 		Username: user.Username,
@@ -317,12 +344,28 @@ func (u *userUsecase) GetUserById(id uint) (res dtos.UserProfileResponse, err er
 		return res, errors.New("User not found")
 	}
 
+	quizScores, err := u.quizRepository.GetQuizScores(user.ID)
+	if err != nil {
+		return res, errors.New("Failed to get quiz scores")
+	}
+
 	res = dtos.UserProfileResponse{
-		ID:       user.ID,
-		Username: user.Username,
-		Nama:     user.FullName,
-		Email:    user.Email,
-		Role:     user.Role,
+		ID:         user.ID,
+		Username:   user.Username,
+		Nama:       user.FullName,
+		Email:      user.Email,
+		Role:       user.Role,
+		QuizScores: []dtos.UserQuizScoreResponse{},
+	}
+
+	for _, quizScore := range quizScores {
+		res.QuizScores = append(res.QuizScores, dtos.UserQuizScoreResponse{
+			ArticleID:    quizScore.ArticleID,
+			ArticleTitle: quizScore.ArticleTitle,
+			BestScore:    quizScore.BestScore,
+			Attempts:     quizScore.Attempts,
+			Passed:       quizScore.Passed,
+		})
 	}
 
 	return res, nil
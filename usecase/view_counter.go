@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"go_bedu/repositories"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Counts article views in memory and writes them in batches so reading an
+// article never waits for an UPDATE. A viewer is counted once per article
+// within the dedup window.
+type ViewCounter interface {
+	Start()
+	Stop()
+	RecordView(articleID uint, viewer string)
+	Flush()
+}
+
+type viewCounter struct {
+	articleRepository repositories.ArticleRepository
+	interval          time.Duration
+	window            time.Duration
+	stop              chan struct{}
+
+	mu      sync.Mutex
+	running bool
+	pending map[uint]int
+	seen    map[string]time.Time
+}
+
+func NewViewCounter(articleRepository repositories.ArticleRepository, interval time.Duration, window time.Duration) ViewCounter {
+	return &viewCounter{
+		articleRepository: articleRepository,
+		interval:          interval,
+		window:            window,
+		stop:              make(chan struct{}),
+		pending:           make(map[uint]int),
+		seen:              make(map[string]time.Time),
+	}
+}
+
+// Start background loop that writes buffered views every interval
+func (c *viewCounter) Start() {
+	c.mu.Lock()
+	if c.running {
+		c.mu.Unlock()
+		return
+	}
+	c.running = true
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.Flush()
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop background loop and write the remaining views
+func (c *viewCounter) Stop() {
+	c.mu.Lock()
+	if !c.running {
+		c.mu.Unlock()
+		return
+	}
+	c.running = false
+	close(c.stop)
+	c.mu.Unlock()
+
+	c.Flush()
+}
+
+// Buffer one view, repeated views of the same viewer inside the window are ignored
+func (c *viewCounter) RecordView(articleID uint, viewer string) {
+	key := strconv.FormatUint(uint64(articleID), 10) + "|" + viewer
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if last, ok := c.seen[key]; ok && now.Sub(last) < c.window {
+		return
+	}
+	c.seen[key] = now
+	c.pending[articleID]++
+}
+
+// Write buffered views to DB, failed writes are kept for the next flush
+func (c *viewCounter) Flush() {
+	now := time.Now()
+
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[uint]int)
+	for key, last := range c.seen {
+		if now.Sub(last) >= c.window {
+			delete(c.seen, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	err := c.articleRepository.IncrementArticleViews(pending)
+	if err == nil {
+		return
+	}
+
+	log.Printf("view counter: %v", err)
+
+	c.mu.Lock()
+	for articleID, total := range pending {
+		c.pending[articleID] += total
+	}
+	c.mu.Unlock()
+}